@@ -0,0 +1,189 @@
+// Package accuracy scores past forecasts against observed values.
+package accuracy
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+// Bucket holds the scores of every forecast step whose lead time falls in
+// [From, To).
+type Bucket struct {
+	From    time.Duration
+	To      time.Duration
+	Samples int
+
+	// MAPE is the mean absolute percentage error of P50, as a fraction.
+	// NaN when no non-zero actual fell in the bucket.
+	MAPE float64
+
+	// Coverage is the fraction of actuals inside the P10–P90 band.
+	// NaN when the forecasts carry no band.
+	Coverage float64
+
+	// Pinball is the mean pinball loss per quantile key.
+	Pinball map[string]float64
+}
+
+// Report is the result of scoring a set of forecasts.
+type Report struct {
+	Buckets   []Bucket
+	Quantiles []string // Quantile keys ordered by level
+	Forecasts int      // Snapshots with at least one scored step
+	Samples   int      // Scored (step, actual) pairs across all buckets
+}
+
+type accumulator struct {
+	samples      int
+	apeSum       float64
+	apeN         int
+	coverHits    int
+	coverN       int
+	pinballSum   map[string]float64
+	pinballCount map[string]int
+}
+
+// Score compares each snapshot with the observed values that later came in
+// and groups the results by lead time into buckets of bucketWidth.
+func Score(snapshots []*client.QuantileSnapshotData, observed *client.ObservedSeries, bucketWidth time.Duration) Report {
+	report := Report{}
+	if observed == nil || len(observed.Samples) == 0 || bucketWidth <= 0 {
+		return report
+	}
+
+	accs := make(map[int]*accumulator)
+	levels := make(map[string]float64)
+
+	for _, snapshot := range snapshots {
+		if snapshot == nil || snapshot.Snapshot.StepSeconds <= 0 {
+			continue
+		}
+
+		snap := snapshot.Snapshot
+		step := time.Duration(snap.StepSeconds) * time.Second
+
+		p50 := snap.Quantiles["p50"]
+		if len(p50) == 0 {
+			p50 = snap.Values
+		}
+		p10, p90 := snap.Quantiles["p10"], snap.Quantiles["p90"]
+
+		n := len(p50)
+		for _, values := range snap.Quantiles {
+			n = max(n, len(values))
+		}
+		actuals := observed.Align(snap.GeneratedAt, step, n)
+
+		scored := false
+		for i, actual := range actuals {
+			if math.IsNaN(actual) {
+				continue
+			}
+
+			lead := time.Duration(i) * step
+			bucket := int(lead / bucketWidth)
+			acc, ok := accs[bucket]
+			if !ok {
+				acc = &accumulator{
+					pinballSum:   make(map[string]float64),
+					pinballCount: make(map[string]int),
+				}
+				accs[bucket] = acc
+			}
+			acc.samples++
+			scored = true
+
+			if i < len(p50) && actual != 0 {
+				acc.apeSum += math.Abs(actual-p50[i]) / math.Abs(actual)
+				acc.apeN++
+			}
+
+			if i < len(p10) && i < len(p90) {
+				acc.coverN++
+				if actual >= p10[i] && actual <= p90[i] {
+					acc.coverHits++
+				}
+			}
+
+			for key, values := range snap.Quantiles {
+				level, ok := client.QuantileLevel(key)
+				if !ok || i >= len(values) {
+					continue
+				}
+				levels[key] = level
+				acc.pinballSum[key] += PinballLoss(level, actual, values[i])
+				acc.pinballCount[key]++
+			}
+		}
+
+		if scored {
+			report.Forecasts++
+		}
+	}
+
+	indexes := make([]int, 0, len(accs))
+	for idx := range accs {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	for _, idx := range indexes {
+		acc := accs[idx]
+		bucket := Bucket{
+			From:     time.Duration(idx) * bucketWidth,
+			To:       time.Duration(idx+1) * bucketWidth,
+			Samples:  acc.samples,
+			MAPE:     math.NaN(),
+			Coverage: math.NaN(),
+			Pinball:  make(map[string]float64, len(acc.pinballSum)),
+		}
+		if acc.apeN > 0 {
+			bucket.MAPE = acc.apeSum / float64(acc.apeN)
+		}
+		if acc.coverN > 0 {
+			bucket.Coverage = float64(acc.coverHits) / float64(acc.coverN)
+		}
+		for key, sum := range acc.pinballSum {
+			bucket.Pinball[key] = sum / float64(acc.pinballCount[key])
+		}
+
+		report.Buckets = append(report.Buckets, bucket)
+		report.Samples += acc.samples
+	}
+
+	for key := range levels {
+		report.Quantiles = append(report.Quantiles, key)
+	}
+	sort.Slice(report.Quantiles, func(i, j int) bool {
+		return levels[report.Quantiles[i]] < levels[report.Quantiles[j]]
+	})
+
+	return report
+}
+
+// BucketWidth picks a lead-time bucket width that splits the horizon into
+// roughly six buckets, rounded up to a whole number of steps.
+func BucketWidth(stepSeconds, horizonSeconds int) time.Duration {
+	if stepSeconds <= 0 {
+		return 0
+	}
+
+	steps := (horizonSeconds/stepSeconds + 5) / 6
+	if steps < 1 {
+		steps = 1
+	}
+	return time.Duration(steps*stepSeconds) * time.Second
+}
+
+// PinballLoss returns the quantile loss of predicting forecast at level q
+// when actual was observed.
+func PinballLoss(q, actual, forecast float64) float64 {
+	diff := actual - forecast
+	if diff >= 0 {
+		return q * diff
+	}
+	return (q - 1) * diff
+}
@@ -0,0 +1,150 @@
+package accuracy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+var generatedAt = time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+func TestScore(t *testing.T) {
+	snapshot := func(quantiles map[string][]float64) *client.QuantileSnapshotData {
+		return &client.QuantileSnapshotData{Snapshot: client.QuantileSnapshot{
+			GeneratedAt: generatedAt,
+			StepSeconds: 60,
+			Quantiles:   quantiles,
+		}}
+	}
+	band := map[string][]float64{
+		"p90": {20, 100, 250},
+		"p50": {10, 110, 180},
+		"p10": {0, 90, 210},
+	}
+	observed := func(values map[time.Duration]float64) *client.ObservedSeries {
+		series := &client.ObservedSeries{}
+		for _, offset := range []time.Duration{0, time.Minute, 2 * time.Minute} {
+			if v, ok := values[offset]; ok {
+				series.Samples = append(series.Samples, client.Sample{Time: generatedAt.Add(offset), Value: v})
+			}
+		}
+		return series
+	}
+
+	tests := []struct {
+		name      string
+		quantiles map[string][]float64
+		actuals   map[time.Duration]float64
+		width     time.Duration
+		want      []string // "from-to samples mape coverage"
+	}{
+		{
+			// The zero actual counts towards coverage but not MAPE, and
+			// actuals on P10 or P90 are inside the band
+			name:      "zero actual",
+			quantiles: band,
+			actuals:   map[time.Duration]float64{0: 0, time.Minute: 100, 2 * time.Minute: 200},
+			width:     time.Hour,
+			want:      []string{"0s-1h0m0s 3 0.1000 0.6667"},
+		},
+		{
+			name:      "only zero actuals",
+			quantiles: band,
+			actuals:   map[time.Duration]float64{0: 0},
+			width:     time.Hour,
+			want:      []string{"0s-1h0m0s 1 NaN 1.0000"},
+		},
+		{
+			// Buckets without actuals are left out
+			name:      "gaps",
+			quantiles: band,
+			actuals:   map[time.Duration]float64{0: 0, 2 * time.Minute: 200},
+			width:     time.Minute,
+			want:      []string{"0s-1m0s 1 NaN 1.0000", "2m0s-3m0s 1 0.1000 0.0000"},
+		},
+		{
+			name:      "no band",
+			quantiles: map[string][]float64{"p50": {10, 110, 180}},
+			actuals:   map[time.Duration]float64{time.Minute: 100},
+			width:     time.Hour,
+			want:      []string{"0s-1h0m0s 1 0.1000 NaN"},
+		},
+		{
+			name:      "no actuals",
+			quantiles: band,
+			width:     time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Score([]*client.QuantileSnapshotData{snapshot(tt.quantiles), nil}, observed(tt.actuals), tt.width)
+
+			var got []string
+			samples := 0
+			for _, b := range report.Buckets {
+				got = append(got, fmt.Sprintf("%v-%v %d %.4f %.4f", b.From, b.To, b.Samples, b.MAPE, b.Coverage))
+				samples += b.Samples
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("buckets = %q, want %q", got, tt.want)
+			}
+			if report.Samples != samples {
+				t.Errorf("Samples = %d, want %d", report.Samples, samples)
+			}
+			if wantForecasts := min(len(tt.want), 1); report.Forecasts != wantForecasts {
+				t.Errorf("Forecasts = %d, want %d", report.Forecasts, wantForecasts)
+			}
+		})
+	}
+
+	report := Score([]*client.QuantileSnapshotData{snapshot(band)}, observed(map[time.Duration]float64{0: 0}), time.Hour)
+	if got := strings.Join(report.Quantiles, ","); got != "p10,p50,p90" {
+		t.Errorf("Quantiles = %s, want p10,p50,p90", got)
+	}
+	if got := report.Buckets[0].Pinball["p50"]; got != 5 {
+		t.Errorf("p50 pinball = %v, want 5", got)
+	}
+}
+
+func TestBucketWidth(t *testing.T) {
+	tests := []struct {
+		step, horizon int
+		want          time.Duration
+	}{
+		{step: 60, horizon: 1800, want: 5 * time.Minute},
+		{step: 60, horizon: 3600, want: 10 * time.Minute},
+		{step: 30, horizon: 100, want: 30 * time.Second},
+		{step: 60, horizon: 0, want: time.Minute},
+		{step: 0, horizon: 1800, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := BucketWidth(tt.step, tt.horizon); got != tt.want {
+			t.Errorf("BucketWidth(%d, %d) = %v, want %v", tt.step, tt.horizon, got, tt.want)
+		}
+	}
+}
+
+func TestPinballLoss(t *testing.T) {
+	tests := []struct {
+		q, actual, forecast float64
+		want                float64
+	}{
+		{q: 0.9, actual: 100, forecast: 80, want: 18},
+		{q: 0.9, actual: 80, forecast: 100, want: 2},
+		{q: 0.1, actual: 100, forecast: 80, want: 2},
+		{q: 0.1, actual: 80, forecast: 100, want: 18},
+		{q: 0.5, actual: 80, forecast: 80, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := PinballLoss(tt.q, tt.actual, tt.forecast); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("PinballLoss(%v, %v, %v) = %v, want %v", tt.q, tt.actual, tt.forecast, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package cli implements headless subcommands that print forecaster and
+// scaler data to stdout, or serve mock APIs, without starting the TUI.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/source"
+)
+
+// Exit codes returned by Run.
+const (
+	ExitOK        = 0
+	ExitError     = 1
+	ExitUsage     = 2
+	ExitUnhealthy = 3
+)
+
+// requestTimeout bounds each request made by a headless command.
+const requestTimeout = 10 * time.Second
+
+type command struct {
+	name    string
+	summary string
+	run     func(r *runner, args []string) int
+}
+
+var commands = []command{
+	{"get", "Print the current forecast snapshot for a workload", runGet},
+	{"watch", "Print a summary line for a workload on every refresh", runWatch},
+	{"workloads", "List workloads known to the forecaster", runWorkloads},
+	{"health", "Check forecaster and scaler health; exit code reflects the result", runHealth},
+	{"mock-server", "Serve fake forecaster and scaler APIs for testing the TUI", runMockServer},
+}
+
+// runner carries the output streams shared by all commands.
+type runner struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// IsCommand reports whether name is a headless subcommand.
+func IsCommand(name string) bool {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes the named subcommand with args and returns its exit code.
+func Run(name string, args []string, stdout, stderr io.Writer) int {
+	r := &runner{stdout: stdout, stderr: stderr}
+
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(r, args)
+		}
+	}
+
+	fmt.Fprintf(stderr, "Unknown command %q\n\n", name)
+	Usage(stderr)
+	return ExitUsage
+}
+
+// Usage prints the list of headless subcommands.
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "Headless commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w, "\nRun 'kedastral-tui <command> -h' for command flags.")
+}
+
+// newFlagSet creates the flag set for a command with the shared --output flag.
+func (r *runner) newFlagSet(name string, output *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(r.stderr)
+	fs.StringVar(output, "output", string(formatTable), "Output format: table, json, yaml")
+	fs.StringVar(output, "o", string(formatTable), "Shorthand for --output")
+	return fs
+}
+
+// parse parses the command flags and validates the required settings.
+func (r *runner) parse(fs *flag.FlagSet, args []string, output *string, needWorkload bool) (*config.Config, format, int) {
+	cfg, _, err := config.Parse(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, "", ExitOK
+		}
+		fmt.Fprintf(r.stderr, "Error: %v\n", err)
+		return nil, "", ExitUsage
+	}
+
+	out, err := parseFormat(*output)
+	if err != nil {
+		fmt.Fprintf(r.stderr, "Error: %v\n", err)
+		return nil, "", ExitUsage
+	}
+
+	if cfg.ForecasterURL == "" {
+		fmt.Fprintln(r.stderr, "Error: --forecaster-url is required")
+		return nil, "", ExitUsage
+	}
+	if needWorkload && cfg.Workload == "" {
+		fmt.Fprintln(r.stderr, "Error: --workload is required")
+		return nil, "", ExitUsage
+	}
+
+	return cfg, out, ExitOK
+}
+
+// fail prints err and returns the generic error exit code.
+func (r *runner) fail(err error) int {
+	fmt.Fprintf(r.stderr, "Error: %v\n", err)
+	return ExitError
+}
+
+// newClient creates the API client for a parsed configuration.
+func newClient(cfg *config.Config) (*client.Client, error) {
+	return source.NewClient(cfg)
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+// snapshotOutput is the machine-readable form of a forecast snapshot.
+type snapshotOutput struct {
+	Workload        string               `json:"workload"`
+	Metric          string               `json:"metric"`
+	APIVersion      int                  `json:"apiVersion"`
+	GeneratedAt     time.Time            `json:"generatedAt"`
+	AgeSeconds      float64              `json:"ageSeconds"`
+	Stale           bool                 `json:"stale"`
+	StepSeconds     int                  `json:"stepSeconds"`
+	HorizonSeconds  int                  `json:"horizonSeconds"`
+	LeadTimeIndex   int                  `json:"leadTimeIndex"`
+	Quantiles       map[string][]float64 `json:"quantiles"`
+	DesiredReplicas []int                `json:"desiredReplicas"`
+}
+
+func newSnapshotOutput(data *client.QuantileSnapshotData) snapshotOutput {
+	snap := data.Snapshot
+	return snapshotOutput{
+		Workload:        snap.Workload,
+		Metric:          snap.Metric,
+		APIVersion:      data.APIVersion,
+		GeneratedAt:     snap.GeneratedAt,
+		AgeSeconds:      data.ForecastAge.Seconds(),
+		Stale:           data.Stale,
+		StepSeconds:     snap.StepSeconds,
+		HorizonSeconds:  snap.HorizonSeconds,
+		LeadTimeIndex:   data.LeadTimeIndex,
+		Quantiles:       snap.Quantiles,
+		DesiredReplicas: snap.DesiredReplicas,
+	}
+}
+
+func runGet(r *runner, args []string) int {
+	var output string
+	fs := r.newFlagSet("get", &output)
+	fs.Usage = func() {
+		fmt.Fprintln(r.stderr, "Usage: kedastral-tui get [flags]\n\nPrint the current forecast snapshot for a workload.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	cfg, out, code := r.parse(fs, args, &output, true)
+	if cfg == nil {
+		return code
+	}
+
+	c, err := newClient(cfg)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	data, err := c.GetQuantileSnapshot(ctx, cfg.Workload, cfg.LeadTime)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	switch out {
+	case formatJSON:
+		err = writeJSON(r.stdout, newSnapshotOutput(data))
+	case formatYAML:
+		err = writeYAML(r.stdout, newSnapshotOutput(data))
+	default:
+		err = writeSnapshotTable(r.stdout, data)
+	}
+	if err != nil {
+		return r.fail(err)
+	}
+
+	return ExitOK
+}
+
+func writeSnapshotTable(w io.Writer, data *client.QuantileSnapshotData) error {
+	snap := data.Snapshot
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Workload:\t%s\n", snap.Workload)
+	fmt.Fprintf(tw, "Metric:\t%s\n", snap.Metric)
+	fmt.Fprintf(tw, "API version:\tv%d\n", data.APIVersion)
+	fmt.Fprintf(tw, "Generated at:\t%s (age %s)\n", snap.GeneratedAt.Format(time.RFC3339), data.ForecastAge.Round(time.Second))
+	fmt.Fprintf(tw, "Stale:\t%t\n", data.Stale)
+	fmt.Fprintf(tw, "Step / horizon:\t%ds / %ds\n", snap.StepSeconds, snap.HorizonSeconds)
+	fmt.Fprintln(tw)
+
+	quantiles := snap.SortedQuantiles()
+	header := []string{"OFFSET", "TIME"}
+	for _, q := range quantiles {
+		header = append(header, strings.ToUpper(q.Key))
+	}
+	header = append(header, "REPLICAS", "")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	step := time.Duration(snap.StepSeconds) * time.Second
+	for i, replicas := range snap.DesiredReplicas {
+		offset := step * time.Duration(i)
+		row := []string{"+" + offset.String(), snap.GeneratedAt.Add(offset).Format("15:04:05")}
+		for _, q := range quantiles {
+			if i < len(q.Values) {
+				row = append(row, fmt.Sprintf("%.2f", q.Values[i]))
+			} else {
+				row = append(row, "-")
+			}
+		}
+		row = append(row, fmt.Sprintf("%d", replicas))
+		if i == data.LeadTimeIndex {
+			row = append(row, "<- lead time")
+		} else {
+			row = append(row, "")
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
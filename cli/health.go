@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+)
+
+// componentHealth is the health of a single service.
+type componentHealth struct {
+	URL     string `json:"url"`
+	Healthy bool   `json:"healthy"`
+}
+
+// healthOutput is the machine-readable result of the health command.
+type healthOutput struct {
+	Healthy    bool            `json:"healthy"`
+	Forecaster componentHealth `json:"forecaster"`
+	Scaler     componentHealth `json:"scaler"`
+}
+
+func runHealth(r *runner, args []string) int {
+	var output, require string
+	fs := r.newFlagSet("health", &output)
+	fs.StringVar(&require, "require", "all", "Components that must be healthy for exit code 0: all, forecaster, scaler")
+	fs.Usage = func() {
+		fmt.Fprintln(r.stderr, "Usage: kedastral-tui health [flags]\n\nCheck forecaster and scaler health.\nExits 0 when the required components are healthy, 3 when not, 1 on errors, 2 on usage errors.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	cfg, out, code := r.parse(fs, args, &output, false)
+	if cfg == nil {
+		return code
+	}
+
+	c, err := newClient(cfg)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	forecasterHealthy, scalerHealthy := c.GetHealthStatus(ctx)
+
+	result := healthOutput{
+		Forecaster: componentHealth{URL: cfg.ForecasterURL, Healthy: forecasterHealthy},
+		Scaler:     componentHealth{URL: cfg.ScalerURL, Healthy: scalerHealthy},
+	}
+
+	switch require {
+	case "all":
+		result.Healthy = forecasterHealthy && scalerHealthy
+	case "forecaster":
+		result.Healthy = forecasterHealthy
+	case "scaler":
+		result.Healthy = scalerHealthy
+	default:
+		fmt.Fprintf(r.stderr, "Error: unknown --require value %q (want all, forecaster or scaler)\n", require)
+		return ExitUsage
+	}
+
+	switch out {
+	case formatJSON:
+		err = writeJSON(r.stdout, result)
+	case formatYAML:
+		err = writeYAML(r.stdout, result)
+	default:
+		tw := tabwriter.NewWriter(r.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "COMPONENT\tURL\tSTATUS")
+		fmt.Fprintf(tw, "forecaster\t%s\t%s\n", result.Forecaster.URL, healthLabel(result.Forecaster.Healthy))
+		fmt.Fprintf(tw, "scaler\t%s\t%s\n", result.Scaler.URL, healthLabel(result.Scaler.Healthy))
+		err = tw.Flush()
+	}
+	if err != nil {
+		return r.fail(err)
+	}
+
+	if !result.Healthy {
+		return ExitUnhealthy
+	}
+	return ExitOK
+}
+
+func healthLabel(healthy bool) string {
+	if healthy {
+		return "healthy"
+	}
+	return "unhealthy"
+}
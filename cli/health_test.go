@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRunHealth(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer unhealthy.Close()
+
+	tests := []struct {
+		name       string
+		forecaster string
+		scaler     string
+		require    string
+		want       int
+	}{
+		{name: "healthy", forecaster: healthy.URL, scaler: healthy.URL, require: "all", want: ExitOK},
+		{name: "unhealthy scaler", forecaster: healthy.URL, scaler: unhealthy.URL, require: "all", want: ExitUnhealthy},
+		{name: "scaler not required", forecaster: healthy.URL, scaler: unhealthy.URL, require: "forecaster", want: ExitOK},
+		{name: "unhealthy forecaster", forecaster: unhealthy.URL, scaler: healthy.URL, require: "forecaster", want: ExitUnhealthy},
+		{name: "unknown requirement", forecaster: healthy.URL, scaler: healthy.URL, require: "database", want: ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := []string{"--forecaster-url=" + tt.forecaster, "--scaler-url=" + tt.scaler, "--require=" + tt.require, "--retries=0"}
+			if got := Run("health", args, &stdout, &stderr); got != tt.want {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", got, tt.want, stderr.String())
+			}
+		})
+	}
+}
+
+func TestExitCodesDistinct(t *testing.T) {
+	// Scripts tell an unhealthy service from a failed check by the exit code
+	codes := map[int]string{}
+	for name, code := range map[string]int{"ExitOK": ExitOK, "ExitError": ExitError, "ExitUsage": ExitUsage, "ExitUnhealthy": ExitUnhealthy} {
+		if other, ok := codes[code]; ok {
+			t.Errorf("%s and %s are both %d", name, other, code)
+		}
+		codes[code] = name
+	}
+}
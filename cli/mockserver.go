@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/mockserver"
+)
+
+func runMockServer(r *runner, args []string) int {
+	var forecasterAddr, scalerAddr, workloads, scenarioName, notFound string
+	var apiVersion int
+	var stale bool
+	var errorRate float64
+	var latency time.Duration
+
+	fs := flag.NewFlagSet("mock-server", flag.ContinueOnError)
+	fs.SetOutput(r.stderr)
+	fs.StringVar(&forecasterAddr, "forecaster-addr", "127.0.0.1:8081", "Address to serve the forecaster API on")
+	fs.StringVar(&scalerAddr, "scaler-addr", "127.0.0.1:8082", "Address to serve the scaler endpoints on")
+	fs.StringVar(&workloads, "workloads", "checkout,search,payments", "Comma-separated workloads to serve")
+	fs.StringVar(&scenarioName, "scenario", "healthy", "Preset: "+strings.Join(mockserver.ScenarioNames(), ", "))
+	fs.IntVar(&apiVersion, "api-version", 2, "Forecast API version: 1 (values only) or 2 (quantiles)")
+	fs.BoolVar(&stale, "stale", false, "Mark every forecast stale with the X-Kedastral-Stale header")
+	fs.StringVar(&notFound, "not-found", "", "Comma-separated workloads whose forecast returns 404")
+	fs.DurationVar(&latency, "latency", 0, "Delay before every response, e.g. 2s")
+	fs.Float64Var(&errorRate, "error-rate", 0, "Fraction of requests answered with 500, from 0 to 1")
+	fs.Usage = func() {
+		fmt.Fprintln(r.stderr, "Usage: kedastral-tui mock-server [flags]\n\nServe fake forecaster and scaler APIs with synthetic data.\nFlags override the selected scenario.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	scenario, ok := mockserver.Scenarios[scenarioName]
+	if !ok {
+		fmt.Fprintf(r.stderr, "Error: unknown --scenario %q (want %s)\n", scenarioName, strings.Join(mockserver.ScenarioNames(), ", "))
+		return ExitUsage
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "api-version":
+			scenario.APIVersion = apiVersion
+		case "stale":
+			scenario.Stale = stale
+		case "not-found":
+			scenario.NotFound = splitList(notFound)
+		case "latency":
+			scenario.Latency = latency
+		case "error-rate":
+			scenario.ErrorRate = errorRate
+		}
+	})
+
+	if scenario.APIVersion != 1 && scenario.APIVersion != 2 {
+		fmt.Fprintln(r.stderr, "Error: --api-version must be 1 or 2")
+		return ExitUsage
+	}
+	if scenario.ErrorRate < 0 || scenario.ErrorRate > 1 {
+		fmt.Fprintln(r.stderr, "Error: --error-rate must be between 0 and 1")
+		return ExitUsage
+	}
+	names := splitList(workloads)
+	if len(names) == 0 {
+		fmt.Fprintln(r.stderr, "Error: --workloads must name at least one workload")
+		return ExitUsage
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Fprintf(r.stdout, "Serving %s with scenario %s (API v%d)\n", strings.Join(names, ", "), scenarioName, scenario.APIVersion)
+	fmt.Fprintf(r.stdout, "  forecaster: http://%s\n  scaler:     http://%s\n", forecasterAddr, scalerAddr)
+	fmt.Fprintf(r.stdout, "Connect with: kedastral-tui --forecaster-url=http://%s --scaler-url=http://%s --workload=%s\n", forecasterAddr, scalerAddr, names[0])
+
+	if err := mockserver.New(scenario, names...).ListenAndServe(ctx, forecasterAddr, scalerAddr); err != nil {
+		return r.fail(err)
+	}
+	return ExitOK
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// format is an output format for headless commands.
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+	formatYAML  format = "yaml"
+)
+
+func parseFormat(s string) (format, error) {
+	switch f := format(strings.ToLower(s)); f {
+	case formatTable, formatJSON, formatYAML:
+		return f, nil
+	case "yml":
+		return formatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (want table, json or yaml)", s)
+	}
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeJSONLine writes v as a single line of JSON, for streaming output.
+func writeJSONLine(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeYAML writes v as a YAML document. Values are encoded through their
+// JSON representation so that json struct tags and field order apply.
+func writeYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	node, err := decodeNode(dec)
+	if err != nil {
+		return fmt.Errorf("failed to convert output: %w", err)
+	}
+
+	var b strings.Builder
+	switch {
+	case node.isScalar():
+		b.WriteString(node.scalar)
+		b.WriteString("\n")
+	case node.isEmpty() || node.isFlowList():
+		b.WriteString(node.flow())
+		b.WriteString("\n")
+	default:
+		node.write(&b, 0)
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// yamlNode is a JSON value decoded with its object keys kept in order.
+type yamlNode struct {
+	scalar string
+	keys   []string
+	values []*yamlNode
+	items  []*yamlNode
+	object bool
+	array  bool
+}
+
+func decodeNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			node := &yamlNode{object: true}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				value, err := decodeNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				node.keys = append(node.keys, key)
+				node.values = append(node.values, value)
+			}
+			_, err := dec.Token()
+			return node, err
+		case '[':
+			node := &yamlNode{array: true}
+			for dec.More() {
+				item, err := decodeNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				node.items = append(node.items, item)
+			}
+			_, err := dec.Token()
+			return node, err
+		}
+	case string:
+		return &yamlNode{scalar: quoteYAML(t)}, nil
+	case json.Number:
+		return &yamlNode{scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{scalar: strconv.FormatBool(t)}, nil
+	case nil:
+		return &yamlNode{scalar: "null"}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected token %v", tok)
+}
+
+func (n *yamlNode) isScalar() bool {
+	return !n.object && !n.array
+}
+
+func (n *yamlNode) isEmpty() bool {
+	return (n.object && len(n.keys) == 0) || (n.array && len(n.items) == 0)
+}
+
+// isFlowList reports whether the node is a list of scalars, which is written
+// inline to keep long series readable.
+func (n *yamlNode) isFlowList() bool {
+	if !n.array {
+		return false
+	}
+	for _, item := range n.items {
+		if !item.isScalar() {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *yamlNode) flow() string {
+	if n.object {
+		return "{}"
+	}
+	parts := make([]string, len(n.items))
+	for i, item := range n.items {
+		parts[i] = item.scalar
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// inline returns the node's representation when it fits after a key or
+// dash, or "" when it needs its own block.
+func (n *yamlNode) inline() string {
+	switch {
+	case n.isScalar():
+		return n.scalar
+	case n.isEmpty() || n.isFlowList():
+		return n.flow()
+	default:
+		return ""
+	}
+}
+
+func (n *yamlNode) write(b *strings.Builder, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	if n.object {
+		for i, key := range n.keys {
+			value := n.values[i]
+			if inline := value.inline(); inline != "" {
+				fmt.Fprintf(b, "%s%s: %s\n", pad, quoteYAML(key), inline)
+				continue
+			}
+			fmt.Fprintf(b, "%s%s:\n", pad, quoteYAML(key))
+			value.write(b, indent+2)
+		}
+		return
+	}
+
+	for _, item := range n.items {
+		if inline := item.inline(); inline != "" {
+			fmt.Fprintf(b, "%s- %s\n", pad, inline)
+			continue
+		}
+
+		// Write the nested block, then put the dash on its first line
+		var nested strings.Builder
+		item.write(&nested, indent+2)
+		block := nested.String()
+		b.WriteString(pad)
+		b.WriteString("- ")
+		b.WriteString(block[indent+2:])
+	}
+}
+
+// quoteYAML quotes s when it would otherwise be read as something other
+// than a plain string.
+func quoteYAML(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", ".inf", ".nan":
+		return strconv.Quote(s)
+	}
+
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@` ") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.HasSuffix(s, ":") || strings.HasSuffix(s, " ") ||
+		strings.ContainsAny(s, "\n\t\\") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
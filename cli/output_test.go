@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuoteYAML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"checkout", "checkout"},
+		{"http://localhost:8081", "http://localhost:8081"},
+		{"p50", "p50"},
+		{"", `""`},
+		{"true", `"true"`},
+		{"No", `"No"`},
+		{"null", `"null"`},
+		{"~", `"~"`},
+		{".inf", `".inf"`},
+		{"42", `"42"`},
+		{"1e3", `"1e3"`},
+		{"-5m", `"-5m"`},
+		{"- item", `"- item"`},
+		{"key: value", `"key: value"`},
+		{"trailing:", `"trailing:"`},
+		{"value #comment", `"value #comment"`},
+		{"#comment", `"#comment"`},
+		{"*alias", `"*alias"`},
+		{`say "hi"`, `say "hi"`},
+		{`"quoted"`, `"\"quoted\""`},
+		{"two\nlines", `"two\nlines"`},
+		{`back\slash`, `"back\\slash"`},
+		{" padded ", `" padded "`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteYAML(tt.in); got != tt.want {
+			t.Errorf("quoteYAML(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	type replica struct {
+		Offset string `json:"offset"`
+		Count  int    `json:"count"`
+	}
+	v := struct {
+		Workload  string               `json:"workload"`
+		Stale     bool                 `json:"stale"`
+		Note      *string              `json:"note"`
+		Quantiles map[string][]float64 `json:"quantiles"`
+		Replicas  []replica            `json:"replicas"`
+		Labels    map[string]string    `json:"labels"`
+		Empty     []int                `json:"empty"`
+	}{
+		Workload:  "yes",
+		Quantiles: map[string][]float64{"p10": {1.5, 2}, "p90": {3, 4.25}},
+		Replicas:  []replica{{"+0s", 2}, {"+1m0s", 3}},
+		Labels:    map[string]string{"app": "checkout: v2"},
+		Empty:     []int{},
+	}
+
+	var b strings.Builder
+	if err := writeYAML(&b, v); err != nil {
+		t.Fatalf("writeYAML() error = %v", err)
+	}
+
+	want := `workload: "yes"
+stale: false
+note: null
+quantiles:
+  p10: [1.5, 2]
+  p90: [3, 4.25]
+replicas:
+  - offset: +0s
+    count: 2
+  - offset: +1m0s
+    count: 3
+labels:
+  app: "checkout: v2"
+empty: []
+`
+	if got := b.String(); got != want {
+		t.Errorf("writeYAML() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteYAMLScalar(t *testing.T) {
+	for _, tt := range []struct {
+		in   any
+		want string
+	}{
+		{"on", "\"on\"\n"},
+		{3, "3\n"},
+		{[]string{"a", "b c"}, "[a, b c]\n"},
+		{map[string]int{}, "{}\n"},
+	} {
+		var b strings.Builder
+		if err := writeYAML(&b, tt.in); err != nil {
+			t.Fatalf("writeYAML(%v) error = %v", tt.in, err)
+		}
+		if got := b.String(); got != tt.want {
+			t.Errorf("writeYAML(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]format{"table": formatTable, "JSON": formatJSON, "yml": formatYAML} {
+		if got, err := parseFormat(in); err != nil || got != want {
+			t.Errorf("parseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := parseFormat("xml"); err == nil {
+		t.Errorf("parseFormat(xml) succeeded")
+	}
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+// watchLine is the per-refresh summary printed by the watch command.
+type watchLine struct {
+	Time            time.Time `json:"time"`
+	Workload        string    `json:"workload"`
+	GeneratedAt     time.Time `json:"generatedAt,omitzero"`
+	AgeSeconds      float64   `json:"ageSeconds"`
+	Stale           bool      `json:"stale"`
+	LeadTimeSeconds int       `json:"leadTimeSeconds"`
+	ValueAtLead     float64   `json:"valueAtLead"`
+	ReplicasAtLead  int       `json:"replicasAtLead"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func newWatchLine(now time.Time, workload string, data *client.QuantileSnapshotData, err error) watchLine {
+	line := watchLine{Time: now, Workload: workload}
+	if err != nil {
+		line.Error = err.Error()
+		return line
+	}
+
+	snap := data.Snapshot
+	line.GeneratedAt = snap.GeneratedAt
+	line.AgeSeconds = data.ForecastAge.Seconds()
+	line.Stale = data.Stale
+	line.LeadTimeSeconds = data.LeadTimeIndex * snap.StepSeconds
+
+	values := snap.Quantiles["p50"]
+	if len(values) == 0 {
+		values = snap.Values
+	}
+	if data.LeadTimeIndex < len(values) {
+		line.ValueAtLead = values[data.LeadTimeIndex]
+	}
+	if data.LeadTimeIndex < len(snap.DesiredReplicas) {
+		line.ReplicasAtLead = snap.DesiredReplicas[data.LeadTimeIndex]
+	}
+
+	return line
+}
+
+func runWatch(r *runner, args []string) int {
+	var output string
+	var count int
+	fs := r.newFlagSet("watch", &output)
+	fs.IntVar(&count, "count", 0, "Stop after this many refreshes (0 = until interrupted)")
+	fs.Usage = func() {
+		fmt.Fprintln(r.stderr, "Usage: kedastral-tui watch [flags]\n\nPrint a summary line for a workload on every refresh interval.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	cfg, out, code := r.parse(fs, args, &output, true)
+	if cfg == nil {
+		return code
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	c, err := newClient(cfg)
+	if err != nil {
+		return r.fail(err)
+	}
+	ticker := time.NewTicker(cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	failed := false
+	for n := 0; count == 0 || n < count; n++ {
+		if n > 0 {
+			select {
+			case <-ctx.Done():
+				return ExitOK
+			case <-ticker.C:
+			}
+		}
+
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		data, err := c.GetQuantileSnapshot(reqCtx, cfg.Workload, cfg.LeadTime)
+		cancel()
+		if ctx.Err() != nil {
+			return ExitOK
+		}
+
+		failed = err != nil
+		line := newWatchLine(time.Now(), cfg.Workload, data, err)
+		if err := r.writeWatchLine(out, n, line); err != nil {
+			return r.fail(err)
+		}
+	}
+
+	if failed {
+		return ExitError
+	}
+	return ExitOK
+}
+
+func (r *runner) writeWatchLine(out format, n int, line watchLine) error {
+	switch out {
+	case formatJSON:
+		return writeJSONLine(r.stdout, line)
+	case formatYAML:
+		if _, err := fmt.Fprintln(r.stdout, "---"); err != nil {
+			return err
+		}
+		return writeYAML(r.stdout, line)
+	}
+
+	const row = "%-8s  %-20s  %8s  %-5s  %6s  %12s  %8s\n"
+	if n == 0 {
+		fmt.Fprintf(r.stdout, row, "TIME", "WORKLOAD", "AGE", "STALE", "LEAD", "VALUE@LEAD", "REPLICAS")
+	}
+
+	if line.Error != "" {
+		_, err := fmt.Fprintf(r.stdout, "%-8s  %-20s  error: %s\n", line.Time.Format("15:04:05"), line.Workload, line.Error)
+		return err
+	}
+
+	_, err := fmt.Fprintf(r.stdout, row,
+		line.Time.Format("15:04:05"),
+		line.Workload,
+		(time.Duration(line.AgeSeconds) * time.Second).String(),
+		fmt.Sprintf("%t", line.Stale),
+		(time.Duration(line.LeadTimeSeconds) * time.Second).String(),
+		fmt.Sprintf("%.2f", line.ValueAtLead),
+		fmt.Sprintf("%d", line.ReplicasAtLead),
+	)
+	return err
+}
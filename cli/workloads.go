@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+func runWorkloads(r *runner, args []string) int {
+	var output string
+	fs := r.newFlagSet("workloads", &output)
+	fs.Usage = func() {
+		fmt.Fprintln(r.stderr, "Usage: kedastral-tui workloads [flags]\n\nList workloads known to the forecaster.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+
+	cfg, out, code := r.parse(fs, args, &output, false)
+	if cfg == nil {
+		return code
+	}
+
+	c, err := newClient(cfg)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	workloads, err := c.GetWorkloads(ctx)
+	if err != nil {
+		return r.fail(err)
+	}
+	if workloads == nil {
+		workloads = []client.WorkloadInfo{}
+	}
+
+	switch out {
+	case formatJSON:
+		err = writeJSON(r.stdout, workloads)
+	case formatYAML:
+		err = writeYAML(r.stdout, workloads)
+	default:
+		tw := tabwriter.NewWriter(r.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tNAMESPACE\tHEALTHY\tREPLICAS\tLAST FORECAST")
+		for _, w := range workloads {
+			lastForecast := "-"
+			if !w.LastForecast.IsZero() {
+				lastForecast = time.Since(w.LastForecast).Round(time.Second).String() + " ago"
+			}
+			namespace := w.Namespace
+			if namespace == "" {
+				namespace = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%d\t%s\n", w.Name, namespace, w.Healthy, w.CurrentReplicas, lastForecast)
+		}
+		err = tw.Flush()
+	}
+	if err != nil {
+		return r.fail(err)
+	}
+
+	return ExitOK
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Auth holds the credentials used for one endpoint. A token file takes
+// precedence over a static token, which takes precedence over basic auth.
+type Auth struct {
+	BearerToken     string
+	BearerTokenFile string
+	Username        string
+	Password        string
+	CertFile        string // Client certificate for mTLS
+	KeyFile         string // Client key for mTLS
+	CAFile          string // CA bundle used to verify the server
+}
+
+// WithForecasterAuth sets the credentials used for forecaster requests.
+func WithForecasterAuth(auth Auth) Option {
+	return func(c *Client) {
+		c.forecasterAuth = auth
+	}
+}
+
+// WithScalerAuth sets the credentials used for scaler requests.
+func WithScalerAuth(auth Auth) Option {
+	return func(c *Client) {
+		c.scalerAuth = auth
+	}
+}
+
+// WithPrometheusAuth sets the credentials used for Prometheus queries.
+func WithPrometheusAuth(auth Auth) Option {
+	return func(c *Client) {
+		c.prometheusAuth = auth
+	}
+}
+
+// newHTTPClient builds an HTTP client that applies auth to every request.
+func newHTTPClient(auth Auth, timeout time.Duration) (*http.Client, error) {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+
+	if auth.CertFile != "" || auth.KeyFile != "" || auth.CAFile != "" {
+		tlsConfig, err := newTLSConfig(auth)
+		if err != nil {
+			return nil, err
+		}
+		base.TLSClientConfig = tlsConfig
+	}
+
+	transport := &authTransport{base: base, auth: auth}
+	if auth.BearerTokenFile != "" {
+		transport.tokenFile = &tokenFile{path: auth.BearerTokenFile}
+		if _, err := transport.tokenFile.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+	if auth.hasCredentials() {
+		httpClient.CheckRedirect = sameHostRedirect
+	}
+	return httpClient, nil
+}
+
+// hasCredentials reports whether auth adds an Authorization header.
+func (a Auth) hasCredentials() bool {
+	return a.BearerTokenFile != "" || a.BearerToken != "" || a.Username != ""
+}
+
+// sameHostRedirect refuses redirects to another host. The transport adds
+// credentials to every request, so following one would hand them to a
+// server they were not meant for.
+func sameHostRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after 10 redirects")
+	}
+	if req.URL.Host != via[0].URL.Host {
+		return fmt.Errorf("refusing to send credentials on redirect to %s", req.URL.Host)
+	}
+	return nil
+}
+
+func newTLSConfig(auth Auth) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if auth.CertFile != "" || auth.KeyFile != "" {
+		if auth.CertFile == "" || auth.KeyFile == "" {
+			return nil, fmt.Errorf("client certificate and key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(auth.CertFile, auth.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if auth.CAFile != "" {
+		pem, err := os.ReadFile(auth.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", auth.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// authTransport adds credentials to outgoing requests.
+type authTransport struct {
+	base      http.RoundTripper
+	auth      Auth
+	tokenFile *tokenFile
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch {
+	case t.tokenFile != nil:
+		token, err := t.tokenFile.Token()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+token)
+	case t.auth.BearerToken != "":
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "Bearer "+t.auth.BearerToken)
+	case t.auth.Username != "":
+		req = req.Clone(req.Context())
+		req.SetBasicAuth(t.auth.Username, t.auth.Password)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// tokenFile reads a bearer token from disk and re-reads it whenever the
+// file changes, so rotated tokens are picked up without a restart.
+type tokenFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+// Token returns the current token, re-reading the file if it changed.
+func (f *tokenFile) Token() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token != "" && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.token, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token := string(bytes.TrimSpace(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", f.path)
+	}
+
+	f.token = strings.TrimPrefix(token, "Bearer ")
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return f.token, nil
+}
+
+// errTransport fails every request with err. It stands in for an endpoint
+// whose credentials could not be loaded.
+type errTransport struct {
+	err error
+}
+
+func (t errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
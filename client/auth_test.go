@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile writes content to a file in a temporary directory.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// authServer records the Authorization header of the last request.
+func authServer(t *testing.T, header *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*header = r.Header.Get("Authorization")
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// getURL fetches url and fails the test on a transport error.
+func getURL(t *testing.T, httpClient *http.Client, url string) {
+	t.Helper()
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	resp.Body.Close()
+}
+
+func TestAuthPrecedence(t *testing.T) {
+	tokenFile := writeFile(t, "token", "Bearer file-token\n")
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("admin:secret"))
+
+	tests := []struct {
+		name string
+		auth Auth
+		want string
+	}{
+		{
+			name: "token file over static token",
+			auth: Auth{BearerTokenFile: tokenFile, BearerToken: "static", Username: "admin", Password: "secret"},
+			want: "Bearer file-token",
+		},
+		{
+			name: "static token over basic auth",
+			auth: Auth{BearerToken: "static", Username: "admin", Password: "secret"},
+			want: "Bearer static",
+		},
+		{
+			name: "basic auth",
+			auth: Auth{Username: "admin", Password: "secret"},
+			want: basic,
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var header string
+			srv := authServer(t, &header)
+
+			httpClient, err := newHTTPClient(tt.auth, time.Second)
+			if err != nil {
+				t.Fatalf("newHTTPClient: %v", err)
+			}
+			getURL(t, httpClient, srv.URL)
+
+			if header != tt.want {
+				t.Errorf("Authorization = %q, want %q", header, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthTokenFileRotation(t *testing.T) {
+	var header string
+	srv := authServer(t, &header)
+	path := writeFile(t, "token", "first")
+
+	httpClient, err := newHTTPClient(Auth{BearerTokenFile: path}, time.Second)
+	if err != nil {
+		t.Fatalf("newHTTPClient: %v", err)
+	}
+
+	getURL(t, httpClient, srv.URL)
+	if header != "Bearer first" {
+		t.Fatalf("Authorization = %q, want the first token", header)
+	}
+
+	if err := os.WriteFile(path, []byte("rotated"), 0o600); err != nil {
+		t.Fatalf("failed to rotate token: %v", err)
+	}
+	getURL(t, httpClient, srv.URL)
+	if header != "Bearer rotated" {
+		t.Errorf("Authorization = %q, want the rotated token", header)
+	}
+}
+
+func TestAuthErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    Auth
+		wantErr string
+	}{
+		{
+			name:    "empty token file",
+			auth:    Auth{BearerTokenFile: writeFile(t, "token", " \n")},
+			wantErr: "is empty",
+		},
+		{
+			name:    "missing token file",
+			auth:    Auth{BearerTokenFile: filepath.Join(t.TempDir(), "token")},
+			wantErr: "failed to read token file",
+		},
+		{
+			name:    "certificate without key",
+			auth:    Auth{CertFile: "client.crt"},
+			wantErr: "client certificate and key must be set together",
+		},
+		{
+			name:    "key without certificate",
+			auth:    Auth{KeyFile: "client.key"},
+			wantErr: "client certificate and key must be set together",
+		},
+		{
+			name:    "bad CA bundle",
+			auth:    Auth{CAFile: writeFile(t, "ca.pem", "not a certificate")},
+			wantErr: "no certificates found in CA bundle",
+		},
+		{
+			name:    "missing CA bundle",
+			auth:    Auth{CAFile: filepath.Join(t.TempDir(), "ca.pem")},
+			wantErr: "failed to read CA bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newHTTPClient(tt.auth, time.Second)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			// The client reports the error and fails requests to the endpoint
+			c := New("http://forecaster.invalid", "http://scaler.invalid", WithForecasterAuth(tt.auth))
+			if c.Err() == nil || !strings.HasPrefix(c.Err().Error(), "forecaster auth: ") {
+				t.Errorf("Err = %v, want a forecaster auth error", c.Err())
+			}
+			if err := c.CheckForecasterHealth(context.Background()); err == nil {
+				t.Error("CheckForecasterHealth succeeded without credentials")
+			}
+		})
+	}
+}
+
+func TestAuthTLS(t *testing.T) {
+	var header string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	ca := writeFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})))
+	c := New(srv.URL, srv.URL, WithForecasterAuth(Auth{BearerToken: "secret", CAFile: ca}))
+	if err := c.Err(); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := c.CheckForecasterHealth(context.Background()); err != nil {
+		t.Fatalf("CheckForecasterHealth: %v", err)
+	}
+	if header != "Bearer secret" {
+		t.Errorf("Authorization = %q, want the token", header)
+	}
+
+	// Without the CA the server is not trusted
+	untrusted := New(srv.URL, srv.URL, WithRetry(RetryPolicy{}))
+	if err := untrusted.CheckForecasterHealth(context.Background()); err == nil {
+		t.Error("CheckForecasterHealth trusted an unknown CA")
+	}
+}
+
+func TestAuthRedirect(t *testing.T) {
+	var elsewhere string
+	other := authServer(t, &elsewhere)
+
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/healthz", http.StatusFound)
+		case "/elsewhere":
+			http.Redirect(w, r, other.URL+"/healthz", http.StatusFound)
+		default:
+			header = r.Header.Get("Authorization")
+		}
+	}))
+	defer srv.Close()
+
+	httpClient, err := newHTTPClient(Auth{BearerToken: "secret"}, time.Second)
+	if err != nil {
+		t.Fatalf("newHTTPClient: %v", err)
+	}
+
+	// Redirects on the same host keep their credentials
+	getURL(t, httpClient, srv.URL+"/moved")
+	if header != "Bearer secret" {
+		t.Errorf("Authorization after a same-host redirect = %q, want the token", header)
+	}
+
+	resp, err := httpClient.Get(srv.URL + "/elsewhere")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("followed a redirect to another host")
+	}
+	if !strings.Contains(err.Error(), "refusing to send credentials") {
+		t.Errorf("err = %v, want a refused redirect", err)
+	}
+	if elsewhere != "" {
+		t.Errorf("other host received Authorization %q", elsewhere)
+	}
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/clock"
+)
+
+// BreakerState is the state of an endpoint's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets requests through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects requests until the cooldown has passed.
+	BreakerOpen
+	// BreakerHalfOpen lets a single probe request through.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerStatus is a point-in-time view of an endpoint's circuit breaker.
+type BreakerStatus struct {
+	Endpoint  string
+	State     BreakerState
+	Failures  int
+	NextProbe time.Time // When an open breaker lets the next probe through
+}
+
+// CircuitOpenError is returned for requests rejected by an open breaker.
+type CircuitOpenError struct {
+	Endpoint  string
+	NextProbe time.Time
+	Probing   bool // A probe request is already in flight
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.Probing {
+		return fmt.Sprintf("%s circuit open, probe in progress", e.Endpoint)
+	}
+	wait := max(clock.Until(e.NextProbe).Round(time.Second), time.Second)
+	return fmt.Sprintf("%s circuit open, next probe in %s", e.Endpoint, wait)
+}
+
+// breaker opens after threshold consecutive failures and lets a probe through
+// once cooldown has passed. A zero threshold disables it.
+type breaker struct {
+	endpoint  string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(endpoint string, threshold int, cooldown time.Duration) *breaker {
+	return &breaker{endpoint: endpoint, threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns an error if the request must not be sent.
+func (b *breaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if clock.Since(b.openedAt) < b.cooldown {
+			return &CircuitOpenError{Endpoint: b.endpoint, NextProbe: b.openedAt.Add(b.cooldown)}
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{Endpoint: b.endpoint, NextProbe: b.openedAt.Add(b.cooldown), Probing: true}
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *breaker) failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = clock.Now()
+	}
+}
+
+// release gives up a probe slot without recording an outcome, e.g. when the
+// caller cancelled the request.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *breaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		Endpoint: b.endpoint,
+		State:    b.state,
+		Failures: b.failures,
+	}
+	if b.state != BreakerClosed {
+		status.NextProbe = b.openedAt.Add(b.cooldown)
+	}
+	return status
+}
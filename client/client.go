@@ -8,16 +8,47 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+	"github.com/HatiCode/kedastral-tui/clock"
 )
 
 // Client handles HTTP communication with forecaster and scaler services.
 type Client struct {
 	forecasterURL string
 	scalerURL     string
-	httpClient    *http.Client
+	prometheusURL string
+	observedQuery string
+
+	forecasterAuth Auth
+	scalerAuth     Auth
+	prometheusAuth Auth
+
+	forecasterHTTP *http.Client
+	scalerHTTP     *http.Client
+	prometheusHTTP *http.Client
+	initErr        error
+
+	retry            RetryPolicy
+	breakerThreshold int
+	breakerCooldown  time.Duration
+	breakers         []*breaker
+}
+
+// Option configures optional Client behaviour.
+type Option func(*Client)
+
+// WithPrometheus makes GetObservedValues query a Prometheus-compatible API
+// at url using the given PromQL template instead of the forecaster.
+func WithPrometheus(url, query string) Option {
+	return func(c *Client) {
+		c.prometheusURL = url
+		c.observedQuery = query
+	}
 }
 
-// Snapshot represents a forecast snapshot from the forecaster.
+// Snapshot is a forecast snapshot in the forecaster's v1 format, without
+// quantiles.
 type Snapshot struct {
 	Workload        string    `json:"workload"`
 	Metric          string    `json:"metric"`
@@ -28,14 +59,6 @@ type Snapshot struct {
 	DesiredReplicas []int     `json:"desiredReplicas"`
 }
 
-// SnapshotData contains enriched snapshot information for display.
-type SnapshotData struct {
-	Snapshot      Snapshot
-	Stale         bool
-	ForecastAge   time.Duration
-	LeadTimeIndex int
-}
-
 // QuantileSnapshot represents a forecast snapshot with quantile data (API v2).
 type QuantileSnapshot struct {
 	Workload        string               `json:"workload"`
@@ -48,7 +71,10 @@ type QuantileSnapshot struct {
 	DesiredReplicas []int                `json:"desiredReplicas"`
 }
 
-// QuantileSnapshotData contains enriched quantile snapshot information.
+// QuantileSnapshotData contains enriched quantile snapshot information. It is
+// the forecast type the UI reads for both API versions: a v1 forecast's
+// values double as its P50 quantile, and a v2 forecast's P50 fills in values
+// the forecaster omits.
 type QuantileSnapshotData struct {
 	Snapshot      QuantileSnapshot
 	Stale         bool
@@ -72,17 +98,65 @@ type ScalerMetrics struct {
 	ForecastAgeSeen   float64
 	DesiredReplicas   int
 	ConnectionHealthy bool
+	Families          *promtext.Set // All scaler metric families for the workload
 }
 
 // New creates a new Client instance.
-func New(forecasterURL, scalerURL string) *Client {
-	return &Client{
-		forecasterURL: forecasterURL,
-		scalerURL:     scalerURL,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+// If credentials for an endpoint cannot be loaded, requests to that endpoint
+// fail and Err reports why.
+func New(forecasterURL, scalerURL string, opts ...Option) *Client {
+	c := &Client{
+		forecasterURL:    forecasterURL,
+		scalerURL:        scalerURL,
+		retry:            DefaultRetryPolicy,
+		breakerThreshold: DefaultBreakerThreshold,
+		breakerCooldown:  DefaultBreakerCooldown,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	c.forecasterHTTP = c.buildHTTPClient("forecaster", c.forecasterAuth)
+	c.scalerHTTP = c.buildHTTPClient("scaler", c.scalerAuth)
+	c.prometheusHTTP = c.buildHTTPClient("prometheus", c.prometheusAuth)
+
+	return c
+}
+
+// Err returns the first error encountered while setting up endpoint
+// credentials, or nil.
+func (c *Client) Err() error {
+	return c.initErr
+}
+
+func (c *Client) buildHTTPClient(endpoint string, auth Auth) *http.Client {
+	httpClient, err := newHTTPClient(auth, 10*time.Second)
+	if err != nil {
+		err = fmt.Errorf("%s auth: %w", endpoint, err)
+		if c.initErr == nil {
+			c.initErr = err
+		}
+		return &http.Client{Transport: errTransport{err: err}}
+	}
+
+	b := newBreaker(endpoint, c.breakerThreshold, c.breakerCooldown)
+	c.breakers = append(c.breakers, b)
+	httpClient.Transport = &resilientTransport{base: httpClient.Transport, policy: c.retry, breaker: b}
+
+	return httpClient
+}
+
+// BreakerStatuses returns the circuit breaker state of each endpoint in use.
+func (c *Client) BreakerStatuses() []BreakerStatus {
+	statuses := make([]BreakerStatus, 0, len(c.breakers))
+	for _, b := range c.breakers {
+		if b.endpoint == "prometheus" && c.prometheusURL == "" {
+			continue
+		}
+		statuses = append(statuses, b.status())
+	}
+	return statuses
 }
 
 // GetWorkloads fetches the list of available workloads.
@@ -94,7 +168,7 @@ func (c *Client) GetWorkloads(ctx context.Context) ([]WorkloadInfo, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.forecasterHTTP.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch workloads: %w", err)
 	}
@@ -129,7 +203,7 @@ func (c *Client) GetQuantileSnapshot(ctx context.Context, workload string, leadT
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.forecasterHTTP.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch snapshot: %w", err)
 	}
@@ -145,10 +219,21 @@ func (c *Client) GetQuantileSnapshot(ctx context.Context, workload string, leadT
 		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
 	}
 
+	stale := resp.Header.Get("X-Kedastral-Stale") == "true"
+
+	return NewQuantileSnapshotData(snapshot, stale, leadTime), nil
+}
+
+// NewQuantileSnapshotData enriches a snapshot with its age, API version and
+// the step index matching leadTime.
+func NewQuantileSnapshotData(snapshot QuantileSnapshot, stale bool, leadTime time.Duration) *QuantileSnapshotData {
 	// Detect API version based on presence of quantiles
 	apiVersion := 1
 	if len(snapshot.Quantiles) > 0 {
 		apiVersion = 2
+		if p50, ok := snapshot.Quantiles["p50"]; ok && len(snapshot.Values) == 0 {
+			snapshot.Values = p50
+		}
 	} else {
 		// For v1 API, populate quantiles from values (treat as P50)
 		if len(snapshot.Values) > 0 {
@@ -158,80 +243,35 @@ func (c *Client) GetQuantileSnapshot(ctx context.Context, workload string, leadT
 		}
 	}
 
-	stale := resp.Header.Get("X-Kedastral-Stale") == "true"
-	forecastAge := time.Since(snapshot.GeneratedAt)
-
-	leadTimeIndex := 0
-	if snapshot.StepSeconds > 0 {
-		stepDuration := time.Duration(snapshot.StepSeconds) * time.Second
-		leadSteps := int(leadTime / stepDuration)
-		if leadSteps >= len(snapshot.DesiredReplicas) {
-			leadSteps = len(snapshot.DesiredReplicas) - 1
-		}
-		if leadSteps > 0 {
-			leadTimeIndex = leadSteps
-		}
-	}
+	forecastAge := clock.Since(snapshot.GeneratedAt)
 
 	return &QuantileSnapshotData{
 		Snapshot:      snapshot,
 		Stale:         stale,
 		ForecastAge:   forecastAge,
-		LeadTimeIndex: leadTimeIndex,
+		LeadTimeIndex: LeadTimeIndex(leadTime, snapshot.StepSeconds, len(snapshot.DesiredReplicas)),
 		APIVersion:    apiVersion,
-	}, nil
-}
-
-// GetSnapshot fetches the current forecast snapshot for the given workload.
-func (c *Client) GetSnapshot(ctx context.Context, workload string, leadTime time.Duration) (*SnapshotData, error) {
-	url := fmt.Sprintf("%s/forecast/current?workload=%s", c.forecasterURL, workload)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch snapshot: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("forecaster returned status %d: %s", resp.StatusCode, string(body))
 	}
+}
 
-	var snapshot Snapshot
-	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
-		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+// LeadTimeIndex returns the step matching leadTime in a forecast of steps
+// steps of stepSeconds each.
+func LeadTimeIndex(leadTime time.Duration, stepSeconds, steps int) int {
+	if stepSeconds <= 0 {
+		return 0
 	}
 
-	stale := resp.Header.Get("X-Kedastral-Stale") == "true"
-	forecastAge := time.Since(snapshot.GeneratedAt)
-
-	leadTimeIndex := 0
-	if snapshot.StepSeconds > 0 {
-		stepDuration := time.Duration(snapshot.StepSeconds) * time.Second
-		leadSteps := int(leadTime / stepDuration)
-		if leadSteps >= len(snapshot.DesiredReplicas) {
-			leadSteps = len(snapshot.DesiredReplicas) - 1
-		}
-		if leadSteps > 0 {
-			leadTimeIndex = leadSteps
-		}
+	stepDuration := time.Duration(stepSeconds) * time.Second
+	leadSteps := int(leadTime / stepDuration)
+	if leadSteps >= steps {
+		leadSteps = steps - 1
 	}
-
-	return &SnapshotData{
-		Snapshot:      snapshot,
-		Stale:         stale,
-		ForecastAge:   forecastAge,
-		LeadTimeIndex: leadTimeIndex,
-	}, nil
+	return max(leadSteps, 0)
 }
 
-// GetScalerMetrics fetches and parses metrics from the scaler.
-func (c *Client) GetScalerMetrics(ctx context.Context) (*ScalerMetrics, error) {
+// GetScalerMetrics fetches and parses metrics from the scaler, keeping only
+// series that belong to workload.
+func (c *Client) GetScalerMetrics(ctx context.Context, workload string) (*ScalerMetrics, error) {
 	url := fmt.Sprintf("%s/metrics", c.scalerURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -239,7 +279,7 @@ func (c *Client) GetScalerMetrics(ctx context.Context) (*ScalerMetrics, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.scalerHTTP.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch metrics: %w", err)
 	}
@@ -249,106 +289,85 @@ func (c *Client) GetScalerMetrics(ctx context.Context) (*ScalerMetrics, error) {
 		return nil, fmt.Errorf("scaler returned status %d", resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	set, err := promtext.Parse(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read metrics: %w", err)
-	}
-
-	metrics := &ScalerMetrics{
-		ConnectionHealthy: true,
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
 	}
 
-	metrics.parsePrometheusMetrics(string(body))
-
-	return metrics, nil
+	return NewScalerMetrics(set, workload), nil
 }
 
 // GetHealthStatus checks the health of both forecaster and scaler.
 func (c *Client) GetHealthStatus(ctx context.Context) (forecasterHealthy, scalerHealthy bool) {
-	forecasterHealthy = c.checkHealth(ctx, c.forecasterURL)
-	scalerHealthy = c.checkHealth(ctx, c.scalerURL)
+	forecasterHealthy = c.CheckForecasterHealth(ctx) == nil
+	scalerHealthy = c.CheckScalerHealth(ctx) == nil
 	return
 }
 
-func (c *Client) checkHealth(ctx context.Context, baseURL string) bool {
+// CheckForecasterHealth returns an error if the forecaster is not healthy.
+func (c *Client) CheckForecasterHealth(ctx context.Context) error {
+	return c.checkHealth(ctx, c.forecasterHTTP, c.forecasterURL)
+}
+
+// CheckScalerHealth returns an error if the scaler is not healthy.
+func (c *Client) CheckScalerHealth(ctx context.Context) error {
+	return c.checkHealth(ctx, c.scalerHTTP, c.scalerURL)
+}
+
+func (c *Client) checkHealth(ctx context.Context, httpClient *http.Client, baseURL string) error {
 	url := fmt.Sprintf("%s/healthz", baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return false
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return false
+		return fmt.Errorf("failed to check health: %w", err)
 	}
 	defer resp.Body.Close()
 
-	return resp.StatusCode == http.StatusOK
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+
+	return nil
 }
 
-// parsePrometheusMetrics parses Prometheus text format metrics.
-func (m *ScalerMetrics) parsePrometheusMetrics(body string) {
-	lines := splitLines(body)
+// Scaler metric names read into ScalerMetrics.
+const (
+	metricDesiredReplicas = "kedastral_scaler_desired_replicas_returned"
+	metricForecastAgeSeen = "kedastral_scaler_forecast_age_seen_seconds"
+	metricGRPCRequests    = "kedastral_scaler_grpc_requests_total"
+)
 
-	for _, line := range lines {
-		if len(line) == 0 || line[0] == '#' {
-			continue
-		}
+// WorkloadLabel is the label the scaler uses to identify a workload.
+const WorkloadLabel = "workload"
 
-		if contains(line, "kedastral_scaler_desired_replicas_returned") {
-			if val := extractValue(line); val != 0 {
-				m.DesiredReplicas = int(val)
-			}
-		} else if contains(line, "kedastral_scaler_forecast_age_seen_seconds") {
-			m.ForecastAgeSeen = extractValue(line)
-		} else if contains(line, "kedastral_scaler_grpc_requests_total") && contains(line, `status="active"`) {
-			if extractValue(line) > 0 {
-				m.Active = true
-			}
-		}
+// NewScalerMetrics builds ScalerMetrics from the scaler's metric families,
+// keeping only series for workload. Series without a workload label apply to
+// every workload and are kept.
+func NewScalerMetrics(set *promtext.Set, workload string) *ScalerMetrics {
+	filtered := set.Filter(func(_ *promtext.Family, s promtext.Sample) bool {
+		name, ok := s.Labels[WorkloadLabel]
+		return !ok || workload == "" || name == workload
+	})
+
+	m := &ScalerMetrics{
+		ConnectionHealthy: true,
+		Families:          filtered,
 	}
-}
 
-func splitLines(s string) []string {
-	var lines []string
-	var current string
-	for _, ch := range s {
-		if ch == '\n' {
-			lines = append(lines, current)
-			current = ""
-		} else {
-			current += string(ch)
-		}
+	if val, ok := filtered.Family(metricDesiredReplicas).Value(nil); ok {
+		m.DesiredReplicas = int(val)
 	}
-	if current != "" {
-		lines = append(lines, current)
+	if val, ok := filtered.Family(metricForecastAgeSeen).Value(nil); ok {
+		m.ForecastAgeSeen = val
 	}
-	return lines
-}
-
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && findSubstring(s, substr)
-}
-
-func findSubstring(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
+	if filtered.Family(metricGRPCRequests).Sum(promtext.Labels{"status": "active"}) > 0 {
+		m.Active = true
 	}
-	return false
-}
 
-func extractValue(line string) float64 {
-	for i := len(line) - 1; i >= 0; i-- {
-		if line[i] == ' ' || line[i] == '\t' {
-			var val float64
-			if _, err := fmt.Sscanf(line[i+1:], "%f", &val); err == nil {
-				return val
-			}
-			break
-		}
-	}
-	return 0
+	return m
 }
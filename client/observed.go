@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultObservedQuery is the PromQL template used to fetch observed values
+// when none is configured. $metric and $workload are substituted.
+const DefaultObservedQuery = `$metric{workload="$workload"}`
+
+// Sample is a single observed metric value.
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// ObservedSeries contains observed values of a workload metric, oldest first.
+type ObservedSeries struct {
+	Workload string   `json:"workload"`
+	Metric   string   `json:"metric"`
+	Samples  []Sample `json:"samples"`
+}
+
+// Align returns one observed value per forecast step starting at start.
+// Steps without a sample within half a step are NaN.
+func (o *ObservedSeries) Align(start time.Time, step time.Duration, n int) []float64 {
+	aligned := make([]float64, n)
+	for i := range aligned {
+		aligned[i] = math.NaN()
+	}
+
+	if o == nil || len(o.Samples) == 0 || step <= 0 {
+		return aligned
+	}
+
+	tolerance := step / 2
+	for i := range aligned {
+		target := start.Add(time.Duration(i) * step)
+
+		j := sort.Search(len(o.Samples), func(k int) bool {
+			return !o.Samples[k].Time.Before(target)
+		})
+
+		best := -1
+		bestDiff := tolerance + 1
+		for _, k := range []int{j - 1, j} {
+			if k < 0 || k >= len(o.Samples) {
+				continue
+			}
+			diff := o.Samples[k].Time.Sub(target)
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff < bestDiff {
+				best, bestDiff = k, diff
+			}
+		}
+
+		if best >= 0 && bestDiff <= tolerance {
+			aligned[i] = o.Samples[best].Value
+		}
+	}
+
+	return aligned
+}
+
+// GetObservedValues fetches observed values of the workload metric between
+// start and end. Values come from the configured Prometheus-compatible API
+// when set, otherwise from the forecaster.
+func (c *Client) GetObservedValues(ctx context.Context, workload, metric string, start, end time.Time, step time.Duration) (*ObservedSeries, error) {
+	if step < time.Second {
+		step = time.Second
+	}
+
+	if c.prometheusURL != "" {
+		return c.queryPrometheus(ctx, workload, metric, start, end, step)
+	}
+
+	return c.getForecasterActuals(ctx, workload, metric, start, end, step)
+}
+
+func (c *Client) getForecasterActuals(ctx context.Context, workload, metric string, start, end time.Time, step time.Duration) (*ObservedSeries, error) {
+	params := url.Values{}
+	params.Set("workload", workload)
+	params.Set("start", start.UTC().Format(time.RFC3339))
+	params.Set("end", end.UTC().Format(time.RFC3339))
+	params.Set("step", fmt.Sprintf("%ds", int(step.Seconds())))
+	reqURL := fmt.Sprintf("%s/forecast/actuals?%s", c.forecasterURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.forecasterHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch observed values: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Older forecasters don't expose actuals; treat as no observations
+		if resp.StatusCode == http.StatusNotFound {
+			return &ObservedSeries{Workload: workload, Metric: metric}, nil
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("forecaster returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var series ObservedSeries
+	if err := json.NewDecoder(resp.Body).Decode(&series); err != nil {
+		return nil, fmt.Errorf("failed to decode observed values: %w", err)
+	}
+
+	if series.Workload == "" {
+		series.Workload = workload
+	}
+	if series.Metric == "" {
+		series.Metric = metric
+	}
+
+	sort.Slice(series.Samples, func(i, j int) bool {
+		return series.Samples[i].Time.Before(series.Samples[j].Time)
+	})
+
+	return &series, nil
+}
+
+func (c *Client) queryPrometheus(ctx context.Context, workload, metric string, start, end time.Time, step time.Duration) (*ObservedSeries, error) {
+	query := c.observedQuery
+	if query == "" {
+		query = DefaultObservedQuery
+	}
+	query = strings.NewReplacer("$workload", workload, "$metric", metric).Replace(query)
+
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", fmt.Sprintf("%ds", int(step.Seconds())))
+	reqURL := fmt.Sprintf("%s/api/v1/query_range?%s", strings.TrimSuffix(c.prometheusURL, "/"), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.prometheusHTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Values [][2]any `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode prometheus response: %w", err)
+	}
+
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+
+	series := &ObservedSeries{Workload: workload, Metric: metric}
+	if len(response.Data.Result) == 0 {
+		return series, nil
+	}
+
+	for _, pair := range response.Data.Result[0].Values {
+		ts, ok := pair[0].(float64)
+		if !ok {
+			continue
+		}
+		raw, ok := pair[1].(string)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+
+		sec, frac := math.Modf(ts)
+		series.Samples = append(series.Samples, Sample{
+			Time:  time.Unix(int64(sec), int64(frac*1e9)),
+			Value: value,
+		})
+	}
+
+	return series, nil
+}
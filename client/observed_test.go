@@ -0,0 +1,204 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var observedStart = time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+func TestObservedSeriesAlign(t *testing.T) {
+	at := func(offset time.Duration, value float64) Sample {
+		return Sample{Time: observedStart.Add(offset), Value: value}
+	}
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		want    []float64
+	}{
+		{
+			name:    "on the steps",
+			samples: []Sample{at(0, 1), at(time.Minute, 2), at(2*time.Minute, 3), at(3*time.Minute, 4)},
+			want:    []float64{1, 2, 3, 4},
+		},
+		{
+			name:    "gaps",
+			samples: []Sample{at(0, 1), at(3*time.Minute, 4)},
+			want:    []float64{1, math.NaN(), math.NaN(), 4},
+		},
+		{
+			// Each step takes the nearest sample within half a step
+			name:    "off the steps",
+			samples: []Sample{at(-20*time.Second, 1), at(50*time.Second, 2), at(75*time.Second, 9), at(2*time.Minute+20*time.Second, 3)},
+			want:    []float64{1, 2, 3, math.NaN()},
+		},
+		{
+			name:    "past the tolerance",
+			samples: []Sample{at(31*time.Second, 1)},
+			want:    []float64{math.NaN(), 1, math.NaN(), math.NaN()},
+		},
+		{
+			name: "no samples",
+			want: []float64{math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := &ObservedSeries{Samples: tt.samples}
+			if got := series.Align(observedStart, time.Minute, 4); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Align = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilSeries *ObservedSeries
+	if got := nilSeries.Align(observedStart, time.Minute, 2); !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("Align on a nil series = %v, want NaN", got)
+	}
+}
+
+func TestGetObservedValuesPrometheus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		samples string
+		wantErr string
+	}{
+		{
+			name:    "success",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"result":[{"values":[[1773478800,"12.5"],[1773478860.5,"14"],[1773478920,"bad"]]}]}}`,
+			samples: "[{2026-03-14 09:00:00 +0000 UTC 12.5} {2026-03-14 09:01:00.5 +0000 UTC 14}]",
+		},
+		{
+			name:    "no series",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"result":[]}}`,
+			samples: "[]",
+		},
+		{
+			name:    "query error",
+			status:  http.StatusOK,
+			body:    `{"status":"error","error":"parse error"}`,
+			wantErr: "prometheus query failed: parse error",
+		},
+		{
+			name:    "bad status",
+			status:  http.StatusBadRequest,
+			body:    "bad query",
+			wantErr: "prometheus returned status 400: bad query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query_range" {
+					t.Errorf("path = %s, want /api/v1/query_range", r.URL.Path)
+				}
+				query = r.URL.Query().Get("query")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			c := New("http://forecaster.invalid", "http://scaler.invalid", WithPrometheus(srv.URL+"/", `rate($metric{app="$workload"}[1m])`))
+			series, err := c.GetObservedValues(context.Background(), "checkout", "rps", observedStart, observedStart.Add(time.Hour), time.Minute)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetObservedValues: %v", err)
+			}
+			if query != `rate(rps{app="checkout"}[1m])` {
+				t.Errorf("query = %s", query)
+			}
+			if series.Workload != "checkout" || series.Metric != "rps" {
+				t.Errorf("series = %s/%s, want checkout/rps", series.Workload, series.Metric)
+			}
+			samples := make([]string, len(series.Samples))
+			for i, s := range series.Samples {
+				samples[i] = fmt.Sprintf("{%v %v}", s.Time.UTC(), s.Value)
+			}
+			if got := "[" + strings.Join(samples, " ") + "]"; got != tt.samples {
+				t.Errorf("samples = %s, want %s", got, tt.samples)
+			}
+		})
+	}
+}
+
+func TestGetObservedValuesForecaster(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		samples int
+		wantErr string
+	}{
+		{
+			name:    "actuals",
+			status:  http.StatusOK,
+			body:    `{"samples":[{"time":"2026-03-14T09:01:00Z","value":2},{"time":"2026-03-14T09:00:00Z","value":1}]}`,
+			samples: 2,
+		},
+		{
+			// Forecasters without the endpoint have no observations
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   "404 page not found",
+		},
+		{
+			name:    "bad status",
+			status:  http.StatusBadRequest,
+			body:    "unknown workload",
+			wantErr: "forecaster returned status 400: unknown workload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/forecast/actuals" || r.URL.Query().Get("workload") != "checkout" || r.URL.Query().Get("step") != "60s" {
+					t.Errorf("request = %s", r.URL)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			c := New(srv.URL, srv.URL)
+			series, err := c.GetObservedValues(context.Background(), "checkout", "rps", observedStart, observedStart.Add(time.Hour), time.Minute)
+
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetObservedValues: %v", err)
+			}
+			if series.Workload != "checkout" || series.Metric != "rps" || len(series.Samples) != tt.samples {
+				t.Fatalf("series = %s/%s with %d samples, want checkout/rps with %d", series.Workload, series.Metric, len(series.Samples), tt.samples)
+			}
+			// Samples come back oldest first
+			if tt.samples > 1 && series.Samples[0].Value != 1 {
+				t.Errorf("first sample = %v, want the oldest", series.Samples[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+// Package promtext parses the Prometheus text exposition format into a
+// queryable set of metric families.
+package promtext
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MetricType is the type declared for a metric family.
+type MetricType string
+
+const (
+	Counter   MetricType = "counter"
+	Gauge     MetricType = "gauge"
+	Histogram MetricType = "histogram"
+	Summary   MetricType = "summary"
+	Untyped   MetricType = "untyped"
+)
+
+// Labels is a set of label name/value pairs.
+type Labels map[string]string
+
+// Matches reports whether l contains every pair in match.
+func (l Labels) Matches(match Labels) bool {
+	for name, value := range match {
+		if l[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the labels as {a="1",b="2"} with names sorted.
+func (l Labels) String() string {
+	if len(l) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(l))
+	for name := range l {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%q", name, l[name])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// Sample is a single series value.
+type Sample struct {
+	Name      string // Sample name, e.g. foo_bucket for histogram foo
+	Labels    Labels
+	Value     float64
+	Timestamp time.Time // Zero when the exposition carries no timestamp
+}
+
+// jsonSample is the JSON form of a Sample. The value is a string because
+// JSON numbers cannot hold NaN or infinities.
+type jsonSample struct {
+	Name      string     `json:"name"`
+	Labels    Labels     `json:"labels,omitempty"`
+	Value     string     `json:"value"`
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// MarshalJSON encodes the sample, keeping NaN and infinite values.
+func (s Sample) MarshalJSON() ([]byte, error) {
+	js := jsonSample{
+		Name:   s.Name,
+		Labels: s.Labels,
+		Value:  strconv.FormatFloat(s.Value, 'g', -1, 64),
+	}
+	if !s.Timestamp.IsZero() {
+		js.Timestamp = &s.Timestamp
+	}
+	return json.Marshal(js)
+}
+
+// UnmarshalJSON decodes a sample encoded by MarshalJSON.
+func (s *Sample) UnmarshalJSON(data []byte) error {
+	var js jsonSample
+	if err := json.Unmarshal(data, &js); err != nil {
+		return err
+	}
+
+	value, err := parseValue(js.Value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", js.Name, err)
+	}
+
+	*s = Sample{Name: js.Name, Labels: js.Labels, Value: value}
+	if s.Labels == nil {
+		s.Labels = Labels{}
+	}
+	if js.Timestamp != nil {
+		s.Timestamp = *js.Timestamp
+	}
+	return nil
+}
+
+// Key identifies the series of the sample within its family.
+func (s Sample) Key() string {
+	return s.Name + s.Labels.String()
+}
+
+// Family is a metric with its metadata and samples.
+type Family struct {
+	Name    string     `json:"name"`
+	Help    string     `json:"help,omitempty"`
+	Type    MetricType `json:"type"`
+	Samples []Sample   `json:"samples"`
+}
+
+// Value returns the value of the first sample named like the family whose
+// labels match.
+func (f *Family) Value(match Labels) (float64, bool) {
+	if f == nil {
+		return 0, false
+	}
+	for _, s := range f.Samples {
+		if s.Name == f.Name && s.Labels.Matches(match) {
+			return s.Value, true
+		}
+	}
+	return 0, false
+}
+
+// Sum adds the values of all samples named like the family whose labels
+// match.
+func (f *Family) Sum(match Labels) float64 {
+	if f == nil {
+		return 0
+	}
+	var sum float64
+	for _, s := range f.Samples {
+		if s.Name == f.Name && s.Labels.Matches(match) {
+			sum += s.Value
+		}
+	}
+	return sum
+}
+
+// Set is a collection of metric families keyed by name.
+type Set struct {
+	families map[string]*Family
+}
+
+// MarshalJSON encodes the set as a list of families sorted by name.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Families())
+}
+
+// UnmarshalJSON decodes a set encoded by MarshalJSON.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var families []*Family
+	if err := json.Unmarshal(data, &families); err != nil {
+		return err
+	}
+
+	s.families = make(map[string]*Family, len(families))
+	for _, f := range families {
+		s.families[f.Name] = f
+	}
+	return nil
+}
+
+// Family returns the named family, or nil if absent.
+func (s *Set) Family(name string) *Family {
+	if s == nil {
+		return nil
+	}
+	return s.families[name]
+}
+
+// Families returns all families sorted by name.
+func (s *Set) Families() []*Family {
+	if s == nil {
+		return nil
+	}
+	families := make([]*Family, 0, len(s.families))
+	for _, f := range s.families {
+		families = append(families, f)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].Name < families[j].Name
+	})
+	return families
+}
+
+// Len returns the number of families.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.families)
+}
+
+// Filter returns a set holding only the samples for which keep returns
+// true. Families left without samples are dropped.
+func (s *Set) Filter(keep func(family *Family, sample Sample) bool) *Set {
+	out := &Set{families: make(map[string]*Family)}
+	if s == nil {
+		return out
+	}
+
+	for name, f := range s.families {
+		filtered := &Family{Name: f.Name, Help: f.Help, Type: f.Type}
+		for _, sample := range f.Samples {
+			if keep(f, sample) {
+				filtered.Samples = append(filtered.Samples, sample)
+			}
+		}
+		if len(filtered.Samples) > 0 {
+			out.families[name] = filtered
+		}
+	}
+	return out
+}
+
+// Parse reads a text-format exposition.
+func Parse(r io.Reader) (*Set, error) {
+	p := &parser{set: &Set{families: make(map[string]*Family)}}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics: %w", err)
+	}
+
+	return p.set, nil
+}
+
+type parser struct {
+	set *Set
+}
+
+func (p *parser) family(name string) *Family {
+	f, ok := p.set.families[name]
+	if !ok {
+		f = &Family{Name: name, Type: Untyped}
+		p.set.families[name] = f
+	}
+	return f
+}
+
+// familyFor returns the family a sample belongs to, mapping histogram and
+// summary suffixes back to their declared family.
+func (p *parser) familyFor(sampleName string) *Family {
+	if f, ok := p.set.families[sampleName]; ok {
+		return f
+	}
+
+	for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+		base, ok := strings.CutSuffix(sampleName, suffix)
+		if !ok {
+			continue
+		}
+		if f, ok := p.set.families[base]; ok && (f.Type == Histogram || f.Type == Summary) {
+			if suffix != "_bucket" || f.Type == Histogram {
+				return f
+			}
+		}
+	}
+
+	return p.family(sampleName)
+}
+
+func (p *parser) parseLine(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+
+	if line[0] == '#' {
+		return p.parseComment(line)
+	}
+
+	sample, err := parseSample(line)
+	if err != nil {
+		return err
+	}
+
+	f := p.familyFor(sample.Name)
+	f.Samples = append(f.Samples, sample)
+	return nil
+}
+
+func (p *parser) parseComment(line string) error {
+	fields := strings.Fields(line[1:])
+	if len(fields) < 2 {
+		return nil
+	}
+
+	switch fields[0] {
+	case "HELP":
+		rest := strings.TrimSpace(line[1:])
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "HELP"))
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, fields[1]))
+		p.family(fields[1]).Help = unescapeHelp(rest)
+	case "TYPE":
+		if len(fields) < 3 {
+			return fmt.Errorf("TYPE for %s has no type", fields[1])
+		}
+		switch t := MetricType(fields[2]); t {
+		case Counter, Gauge, Histogram, Summary, Untyped:
+			p.family(fields[1]).Type = t
+		default:
+			return fmt.Errorf("unknown metric type %q", fields[2])
+		}
+	}
+
+	// Other comments are ignored
+	return nil
+}
+
+func parseSample(line string) (Sample, error) {
+	sample := Sample{Labels: Labels{}}
+
+	i := 0
+	for i < len(line) && isNameChar(line[i], i == 0) {
+		i++
+	}
+	if i == 0 {
+		return sample, fmt.Errorf("invalid metric name in %q", line)
+	}
+	sample.Name = line[:i]
+
+	if i < len(line) && line[i] == '{' {
+		n, err := parseLabels(line[i:], sample.Labels)
+		if err != nil {
+			return sample, err
+		}
+		i += n
+	}
+
+	fields := strings.Fields(line[i:])
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("expected value and optional timestamp for %s", sample.Name)
+	}
+
+	value, err := parseValue(fields[0])
+	if err != nil {
+		return sample, fmt.Errorf("invalid value for %s: %w", sample.Name, err)
+	}
+	sample.Value = value
+
+	if len(fields) == 2 {
+		ms, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return sample, fmt.Errorf("invalid timestamp for %s: %w", sample.Name, err)
+		}
+		sample.Timestamp = time.UnixMilli(ms)
+	}
+
+	return sample, nil
+}
+
+// parseLabels parses a {name="value",...} block into labels and returns
+// the number of bytes consumed.
+func parseLabels(s string, labels Labels) (int, error) {
+	i := 1 // Skip '{'
+
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',') {
+			i++
+		}
+		if i >= len(s) {
+			return 0, fmt.Errorf("unterminated label set")
+		}
+		if s[i] == '}' {
+			return i + 1, nil
+		}
+
+		start := i
+		for i < len(s) && isNameChar(s[i], i == start) {
+			i++
+		}
+		name := s[start:i]
+		if name == "" {
+			return 0, fmt.Errorf("invalid label name at %q", s[start:])
+		}
+
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i >= len(s) || s[i] != '=' {
+			return 0, fmt.Errorf("expected '=' after label %s", name)
+		}
+		i++
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i >= len(s) || s[i] != '"' {
+			return 0, fmt.Errorf("expected quoted value for label %s", name)
+		}
+		i++
+
+		var value strings.Builder
+		for {
+			if i >= len(s) {
+				return 0, fmt.Errorf("unterminated value for label %s", name)
+			}
+			c := s[i]
+			if c == '"' {
+				i++
+				break
+			}
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case '\\', '"':
+					value.WriteByte(s[i])
+				default:
+					value.WriteByte('\\')
+					value.WriteByte(s[i])
+				}
+				i++
+				continue
+			}
+			value.WriteByte(c)
+			i++
+		}
+
+		labels[name] = value.String()
+	}
+}
+
+func parseValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func isNameChar(c byte, first bool) bool {
+	if c == '_' || c == ':' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
+
+func unescapeHelp(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(s)
+}
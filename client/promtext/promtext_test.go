@@ -0,0 +1,181 @@
+package promtext
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		family string
+		help   string
+		typ    MetricType
+		sample Sample
+	}{
+		{
+			name: "help and type",
+			input: "# HELP http_requests_total Total requests.\\nSplit \\\\ here\n" +
+				"# TYPE http_requests_total counter\n" +
+				"http_requests_total 1027\n",
+			family: "http_requests_total",
+			help:   "Total requests.\nSplit \\ here",
+			typ:    Counter,
+			sample: Sample{Name: "http_requests_total", Labels: Labels{}, Value: 1027},
+		},
+		{
+			name:   "escaped label values",
+			input:  `msg{text="say \"hi\"\nbye",path="C:\\tmp"} 1` + "\n",
+			family: "msg",
+			typ:    Untyped,
+			sample: Sample{Name: "msg", Labels: Labels{"text": "say \"hi\"\nbye", "path": `C:\tmp`}, Value: 1},
+		},
+		{
+			name:   "positive infinity",
+			input:  "# TYPE limit gauge\nlimit{kind=\"max\"} +Inf\n",
+			family: "limit",
+			typ:    Gauge,
+			sample: Sample{Name: "limit", Labels: Labels{"kind": "max"}, Value: math.Inf(1)},
+		},
+		{
+			name:   "negative infinity",
+			input:  "limit -Inf\n",
+			family: "limit",
+			typ:    Untyped,
+			sample: Sample{Name: "limit", Labels: Labels{}, Value: math.Inf(-1)},
+		},
+		{
+			name:   "timestamp",
+			input:  "temp{room=\"a\"} 21.5 1773480600000\n",
+			family: "temp",
+			typ:    Untyped,
+			sample: Sample{Name: "temp", Labels: Labels{"room": "a"}, Value: 21.5, Timestamp: time.UnixMilli(1773480600000)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			f := set.Family(tt.family)
+			if f == nil {
+				t.Fatalf("family %s missing; have %d families", tt.family, set.Len())
+			}
+			if f.Help != tt.help {
+				t.Errorf("help = %q, want %q", f.Help, tt.help)
+			}
+			if f.Type != tt.typ {
+				t.Errorf("type = %q, want %q", f.Type, tt.typ)
+			}
+			if len(f.Samples) != 1 {
+				t.Fatalf("got %d samples, want 1", len(f.Samples))
+			}
+			got := f.Samples[0]
+			if got.Name != tt.sample.Name || got.Value != tt.sample.Value || !got.Timestamp.Equal(tt.sample.Timestamp) {
+				t.Errorf("sample = %s %v @%v, want %s %v @%v",
+					got.Name, got.Value, got.Timestamp, tt.sample.Name, tt.sample.Value, tt.sample.Timestamp)
+			}
+			if got.Labels.String() != tt.sample.Labels.String() {
+				t.Errorf("labels = %s, want %s", got.Labels, tt.sample.Labels)
+			}
+		})
+	}
+}
+
+func TestParseNaN(t *testing.T) {
+	set, err := Parse(strings.NewReader("ratio NaN\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if v, ok := set.Family("ratio").Value(nil); !ok || !math.IsNaN(v) {
+		t.Errorf("Value = %v, %v; want NaN, true", v, ok)
+	}
+}
+
+func TestParseHistogramAndSummary(t *testing.T) {
+	input := `# TYPE latency histogram
+latency_bucket{le="0.1"} 3
+latency_bucket{le="+Inf"} 5
+latency_sum 0.7
+latency_count 5
+# TYPE rpc summary
+rpc{quantile="0.5"} 0.2
+rpc_sum 4
+rpc_count 10
+rpc_bucket 1
+`
+	set, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		family  string
+		typ     MetricType
+		samples []string
+	}{
+		{"latency", Histogram, []string{`latency_bucket{le="0.1"}`, `latency_bucket{le="+Inf"}`, "latency_sum", "latency_count"}},
+		{"rpc", Summary, []string{`rpc{quantile="0.5"}`, "rpc_sum", "rpc_count"}},
+		// Summaries have no buckets, so the suffix stays its own family
+		{"rpc_bucket", Untyped, []string{"rpc_bucket"}},
+	}
+
+	if set.Len() != len(tests) {
+		t.Errorf("got %d families, want %d", set.Len(), len(tests))
+	}
+	for _, tt := range tests {
+		f := set.Family(tt.family)
+		if f == nil {
+			t.Errorf("family %s missing", tt.family)
+			continue
+		}
+		if f.Type != tt.typ {
+			t.Errorf("%s type = %q, want %q", tt.family, f.Type, tt.typ)
+		}
+		var got []string
+		for _, s := range f.Samples {
+			got = append(got, s.Name+s.Labels.String())
+		}
+		if strings.Join(got, " ") != strings.Join(tt.samples, " ") {
+			t.Errorf("%s samples = %v, want %v", tt.family, got, tt.samples)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"invalid name", "1metric 1", "line 1: invalid metric name"},
+		{"missing value", "up", "line 1: expected value and optional timestamp"},
+		{"too many fields", "up 1 2 3", "line 1: expected value and optional timestamp"},
+		{"invalid value", "up one", "line 1: invalid value for up"},
+		{"invalid timestamp", "up 1 noon", "line 1: invalid timestamp for up"},
+		{"unterminated labels", `up{job="api"`, "line 1: unterminated label set"},
+		{"missing equals", `up{job "api"} 1`, "line 1: expected '=' after label job"},
+		{"unquoted value", `up{job=api} 1`, "line 1: expected quoted value for label job"},
+		{"unterminated value", `up{job="api} 1`, "line 1: unterminated value for label job"},
+		{"type without type", "# TYPE up", "line 1: TYPE for up has no type"},
+		{"unknown type", "# TYPE up meter", `line 1: unknown metric type "meter"`},
+		{"later line", "up 1\n\ndown x", "line 3: invalid value for down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("Parse(%q) succeeded, want error", tt.input)
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error = %q, want prefix %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Quantile is one quantile series of a forecast.
+type Quantile struct {
+	Key    string  // Key in the snapshot, such as "p90"
+	Level  float64 // Level between 0 and 1, such as 0.9
+	Values []float64
+}
+
+// QuantileLevel parses a quantile key into its level. The digits of a "p"
+// key are the digits after the decimal point, so "p5" and "p50" are 0.5,
+// "p05" is 0.05, "p005" is 0.005 and "p975" is 0.975. A bare fraction
+// ("0.95") is also accepted. Levels must lie strictly between 0 and 1.
+func QuantileLevel(key string) (float64, bool) {
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	if digits, ok := strings.CutPrefix(key, "p"); ok {
+		if digits == "" || strings.Trim(digits, "0123456789") != "" {
+			return 0, false
+		}
+		key = "0." + digits
+	}
+
+	level, err := strconv.ParseFloat(key, 64)
+	if err != nil || level <= 0 || level >= 1 {
+		return 0, false
+	}
+	return level, true
+}
+
+// SortedQuantiles returns the snapshot's quantiles ordered by level. Keys
+// that don't parse as quantiles are left out.
+func (s QuantileSnapshot) SortedQuantiles() []Quantile {
+	quantiles := make([]Quantile, 0, len(s.Quantiles))
+	for key, values := range s.Quantiles {
+		if level, ok := QuantileLevel(key); ok {
+			quantiles = append(quantiles, Quantile{Key: key, Level: level, Values: values})
+		}
+	}
+
+	sort.Slice(quantiles, func(i, j int) bool {
+		if quantiles[i].Level != quantiles[j].Level {
+			return quantiles[i].Level < quantiles[j].Level
+		}
+		return quantiles[i].Key < quantiles[j].Key
+	})
+	return quantiles
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestQuantileLevel(t *testing.T) {
+	tests := []struct {
+		key  string
+		want float64
+		ok   bool
+	}{
+		{"p5", 0.5, true},
+		{"p50", 0.5, true},
+		{"p500", 0.5, true},
+		{"p05", 0.05, true},
+		{"p005", 0.005, true},
+		{"p0975", 0.0975, true},
+		{"p25", 0.25, true},
+		{"p250", 0.25, true},
+		{"P90", 0.9, true},
+		{" p99 ", 0.99, true},
+		{"p975", 0.975, true},
+		{"p999", 0.999, true},
+		{"p9995", 0.9995, true},
+		{"p100", 0.1, true},
+		{"0.95", 0.95, true},
+		{"p0", 0, false},
+		{"p000", 0, false},
+		{"p97.5", 0, false},
+		{"p0.5", 0, false},
+		{"p", 0, false},
+		{"p-5", 0, false},
+		{"p+5", 0, false},
+		{"p1e1", 0, false},
+		{"p 5", 0, false},
+		{"pX", 0, false},
+		{"1", 0, false},
+		{"0", 0, false},
+		{"-0.5", 0, false},
+		{"median", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := QuantileLevel(tt.key)
+		if ok != tt.ok || math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("QuantileLevel(%q) = %v, %v; want %v, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSortedQuantiles(t *testing.T) {
+	s := QuantileSnapshot{Quantiles: map[string][]float64{
+		"p90": {4}, "p50": {3}, "p5": {3}, "p05": {2}, "p005": {1}, "p97.5": {5}, "mean": {3},
+	}}
+
+	var keys []string
+	for _, q := range s.SortedQuantiles() {
+		keys = append(keys, fmt.Sprintf("%s=%.3f", q.Key, q.Level))
+	}
+	if got := fmt.Sprint(keys); got != "[p005=0.005 p05=0.050 p5=0.500 p50=0.500 p90=0.900]" {
+		t.Errorf("SortedQuantiles = %s", got)
+	}
+}
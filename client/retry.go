@@ -0,0 +1,115 @@
+package client
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retrying
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each further retry
+	MaxDelay   time.Duration // Upper bound for a single delay
+}
+
+// DefaultRetryPolicy is used unless WithRetry is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 2,
+	BaseDelay:  200 * time.Millisecond,
+	MaxDelay:   2 * time.Second,
+}
+
+// Default circuit breaker settings, used unless WithCircuitBreaker is given.
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// WithRetry sets the retry policy for every endpoint.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithCircuitBreaker opens an endpoint's breaker after threshold consecutive
+// failures and probes it again after cooldown. A zero threshold disables the
+// breakers.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+// backoff returns the jittered delay before retry attempt n (starting at 1).
+func (p RetryPolicy) backoff(n int) time.Duration {
+	delay := p.BaseDelay << (n - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Full jitter spreads retries from many clients over the whole window
+	return rand.N(delay) + 1
+}
+
+// resilientTransport retries failed requests and guards the endpoint with a
+// circuit breaker. It only ever sees bodiless GET requests, so every request
+// is safe to resend.
+type resilientTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	breaker *breaker
+}
+
+func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		resp, err = t.base.RoundTrip(req)
+		if !retryable(resp, err) || attempt >= t.policy.MaxRetries || req.Body != nil {
+			break
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(t.policy.backoff(attempt + 1))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			t.breaker.release()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	switch {
+	case req.Context().Err() != nil:
+		// Cancelled by the caller; says nothing about the endpoint
+		t.breaker.release()
+	case retryable(resp, err):
+		t.breaker.failure()
+	default:
+		t.breaker.success()
+	}
+
+	return resp, err
+}
+
+// retryable reports whether a response indicates a transient failure.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
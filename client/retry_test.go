@@ -0,0 +1,158 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/clock"
+)
+
+// countingServer answers every request with status and counts the requests.
+func countingServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestResilientTransportRetries(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+	tests := []struct {
+		name    string
+		status  int
+		retries int
+		want    int32
+	}{
+		{"5xx is retried", http.StatusServiceUnavailable, 2, 3},
+		{"429 is retried", http.StatusTooManyRequests, 2, 3},
+		{"4xx is not retried", http.StatusNotFound, 2, 1},
+		{"success is not retried", http.StatusOK, 2, 1},
+		{"zero retries", http.StatusInternalServerError, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := countingServer(t, tt.status)
+			policy.MaxRetries = tt.retries
+			client := &http.Client{Transport: &resilientTransport{
+				base:    http.DefaultTransport,
+				policy:  policy,
+				breaker: newBreaker("test", 0, 0),
+			}}
+
+			resp, err := client.Get(srv.URL)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if got := calls.Load(); got != tt.want {
+				t.Errorf("server saw %d requests, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := observedStart
+	clock.Set(func() time.Time { return now }, time.UTC)
+	t.Cleanup(clock.Reset)
+
+	b := newBreaker("forecaster", 2, time.Minute)
+
+	b.failure()
+	if err := b.allow(); err != nil || b.status().State != BreakerClosed {
+		t.Fatalf("state = %v, err = %v after one failure; want closed", b.status().State, err)
+	}
+	b.failure()
+	if b.status().State != BreakerOpen {
+		t.Fatalf("state = %v after reaching the threshold, want open", b.status().State)
+	}
+
+	var open *CircuitOpenError
+	now = now.Add(20 * time.Second)
+	if err := b.allow(); !errors.As(err, &open) || open.Probing {
+		t.Fatalf("allow() = %v during cooldown, want a circuit open error", err)
+	}
+	if got := open.Error(); got != "forecaster circuit open, next probe in 40s" {
+		t.Errorf("error = %q, want the time left of the cooldown", got)
+	}
+
+	// Once the cooldown has passed a single probe goes through
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil || b.status().State != BreakerHalfOpen {
+		t.Fatalf("state = %v, err = %v after cooldown; want half-open", b.status().State, err)
+	}
+	if err := b.allow(); !errors.As(err, &open) || !open.Probing {
+		t.Fatalf("allow() = %v with a probe in flight, want a probing error", err)
+	}
+
+	// A failed probe reopens the breaker
+	b.failure()
+	if b.status().State != BreakerOpen {
+		t.Fatalf("state = %v after a failed probe, want open", b.status().State)
+	}
+
+	// A cancelled probe frees the slot without closing the breaker
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() = %v after cooldown", err)
+	}
+	b.release()
+	if err := b.allow(); err != nil || b.status().State != BreakerHalfOpen {
+		t.Fatalf("state = %v, err = %v after a released probe; want half-open", b.status().State, err)
+	}
+
+	// A successful probe closes it
+	b.success()
+	if status := b.status(); status.State != BreakerClosed || status.Failures != 0 {
+		t.Fatalf("state = %v with %d failures after a successful probe, want closed with 0", status.State, status.Failures)
+	}
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	b := newBreaker("forecaster", 0, time.Minute)
+	for range 10 {
+		b.failure()
+	}
+	if err := b.allow(); err != nil || b.status().State != BreakerClosed {
+		t.Fatalf("state = %v, err = %v with a zero threshold; want closed", b.status().State, err)
+	}
+}
+
+func TestResilientTransportOpensBreaker(t *testing.T) {
+	srv, calls := countingServer(t, http.StatusBadGateway)
+	client := &http.Client{Transport: &resilientTransport{
+		base:    http.DefaultTransport,
+		policy:  RetryPolicy{},
+		breaker: newBreaker("scaler", 2, time.Minute),
+	}}
+
+	for range 2 {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	var open *CircuitOpenError
+	if _, err := client.Get(srv.URL); !errors.As(err, &open) {
+		t.Fatalf("Get() error = %v after repeated failures, want a circuit open error", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+}
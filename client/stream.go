@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrStreamUnsupported is returned by StreamQuantileSnapshots when the
+// forecaster has no stream endpoint.
+var ErrStreamUnsupported = errors.New("forecaster does not support streaming")
+
+// streamEvent is the payload of a snapshot event. Stale replaces the
+// X-Kedastral-Stale header of the polling endpoint.
+type streamEvent struct {
+	QuantileSnapshot
+	Stale bool `json:"stale"`
+}
+
+// StreamQuantileSnapshots subscribes to the forecaster's Server-Sent Events
+// endpoint and calls fn for every snapshot event until ctx is cancelled or
+// the stream ends, and always returns a non-nil error. It returns
+// ErrStreamUnsupported if the endpoint does not exist or does not answer
+// with an event stream.
+func (c *Client) StreamQuantileSnapshots(ctx context.Context, workload string, leadTime time.Duration, fn func(*QuantileSnapshotData)) error {
+	params := url.Values{}
+	params.Set("workload", workload)
+	streamURL := fmt.Sprintf("%s/forecast/stream?%s", c.forecasterURL, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+
+	// The stream stays open indefinitely, so drop the client timeout
+	streamHTTP := &http.Client{Transport: c.forecasterHTTP.Transport}
+
+	resp, err := streamHTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open forecast stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrStreamUnsupported
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("forecaster returned status %d: %s", resp.StatusCode, string(body))
+	case !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"):
+		// Anything else, such as a JSON error page, is not a stream
+		return ErrStreamUnsupported
+	}
+
+	err = readEvents(resp.Body, func(event, data string) error {
+		if event != "" && event != "snapshot" {
+			return nil
+		}
+
+		var e streamEvent
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			return fmt.Errorf("failed to decode snapshot event: %w", err)
+		}
+		fn(NewQuantileSnapshotData(e.QuantileSnapshot, e.Stale, leadTime))
+		return nil
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err == nil {
+		err = errors.New("forecast stream closed by forecaster")
+	}
+	return err
+}
+
+// readEvents parses a text/event-stream body and calls fn for every event
+// that carries data.
+func readEvents(r io.Reader, fn func(event, data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var event string
+	var data []string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			if len(data) > 0 {
+				if err := fn(event, strings.Join(data, "\n")); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			// Comment, typically a keep-alive
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read forecast stream: %w", err)
+	}
+	return nil
+}
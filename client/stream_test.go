@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// sseServer serves body on /forecast/stream with the given content type and
+// then closes the stream.
+func sseServer(t *testing.T, contentType, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/forecast/stream" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", got)
+		}
+		w.Header().Set("Content-Type", contentType)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStreamQuantileSnapshots(t *testing.T) {
+	body := `: keep-alive
+
+event: snapshot
+data: {"workload":"checkout","stepSeconds":60,
+data: "quantiles":{"p50":[1,2]},"stale":true}
+
+event: heartbeat
+data: {}
+
+data: {"workload":"checkout","stepSeconds":60,"quantiles":{"p50":[3,4]}}
+
+`
+	srv := sseServer(t, "text/event-stream; charset=utf-8", body)
+	c := New(srv.URL, srv.URL)
+
+	var got []*QuantileSnapshotData
+	err := c.StreamQuantileSnapshots(context.Background(), "checkout", time.Minute, func(data *QuantileSnapshotData) {
+		got = append(got, data)
+	})
+	if err == nil || errors.Is(err, ErrStreamUnsupported) {
+		t.Fatalf("error = %v when the stream closed, want a closed stream error", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d snapshots, want 2", len(got))
+	}
+	if !got[0].Stale || got[1].Stale {
+		t.Errorf("stale = %v, %v; want true, false", got[0].Stale, got[1].Stale)
+	}
+	if p50 := got[1].Snapshot.Quantiles["p50"]; len(p50) != 2 || p50[0] != 3 {
+		t.Errorf("second p50 = %v, want [3 4]", p50)
+	}
+	if got[0].Snapshot.Workload != "checkout" || got[0].Snapshot.StepSeconds != 60 {
+		t.Errorf("first snapshot = %s every %ds, want checkout every 60s", got[0].Snapshot.Workload, got[0].Snapshot.StepSeconds)
+	}
+}
+
+func TestStreamQuantileSnapshotsUnsupported(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		prefix      string // Prepended to the stream path
+	}{
+		{"not found", "text/event-stream", "/missing"},
+		{"not an event stream", "application/json", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := sseServer(t, tt.contentType, `{"error":"unknown path"}`)
+			c := New(srv.URL+tt.prefix, srv.URL)
+			err := c.StreamQuantileSnapshots(context.Background(), "checkout", time.Minute, func(*QuantileSnapshotData) {
+				t.Errorf("unexpected snapshot")
+			})
+			if !errors.Is(err, ErrStreamUnsupported) {
+				t.Errorf("error = %v, want ErrStreamUnsupported", err)
+			}
+		})
+	}
+}
+
+func TestStreamQuantileSnapshotsBadEvent(t *testing.T) {
+	srv := sseServer(t, "text/event-stream", "data: {not json}\n\n")
+	c := New(srv.URL, srv.URL)
+
+	err := c.StreamQuantileSnapshots(context.Background(), "checkout", time.Minute, func(*QuantileSnapshotData) {})
+	if err == nil || errors.Is(err, ErrStreamUnsupported) {
+		t.Fatalf("error = %v, want a decode error", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Package clock provides the current time to everything that renders it, so
+// tests can pin it.
+package clock
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var (
+	now      atomic.Pointer[func() time.Time]
+	location atomic.Pointer[time.Location]
+	replaced atomic.Bool
+)
+
+func init() {
+	Reset()
+}
+
+// Now returns the current time.
+func Now() time.Time {
+	return (*now.Load())()
+}
+
+// Since returns the time elapsed since t.
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Until returns the duration until t.
+func Until(t time.Time) time.Duration {
+	return t.Sub(Now())
+}
+
+// Local returns t in the clock's time zone.
+func Local(t time.Time) time.Time {
+	return t.In(location.Load())
+}
+
+// After waits for d to pass and then sends the clock's time. A replaced clock
+// does not advance on its own, so its timers fire at once.
+func After(d time.Duration) <-chan time.Time {
+	if !replaced.Load() {
+		return time.After(d)
+	}
+	ch := make(chan time.Time, 1)
+	ch <- Now()
+	return ch
+}
+
+// Set replaces the clock with f, reporting times in loc.
+func Set(f func() time.Time, loc *time.Location) {
+	now.Store(&f)
+	location.Store(loc)
+	replaced.Store(true)
+}
+
+// Reset restores the wall clock and the local time zone.
+func Reset() {
+	f := time.Now
+	now.Store(&f)
+	location.Store(time.Local)
+	replaced.Store(false)
+}
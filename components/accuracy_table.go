@@ -0,0 +1,92 @@
+// Package components provides UI components for the TUI.
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/accuracy"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AccuracyTable renders forecast accuracy scores per lead-time bucket.
+type AccuracyTable struct {
+	width int
+}
+
+// NewAccuracyTable creates a new accuracy table component.
+func NewAccuracyTable(width int) *AccuracyTable {
+	return &AccuracyTable{width: width}
+}
+
+// Render renders the accuracy report, highlighting the bucket that contains
+// the configured lead time.
+func (a *AccuracyTable) Render(report *accuracy.Report, leadTime time.Duration) string {
+	if report == nil || len(report.Buckets) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Render("No accuracy data yet. Scores appear once observed values arrive for past forecasts.")
+	}
+
+	var s strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	s.WriteString(headerStyle.Render("FORECAST ACCURACY BY LEAD TIME"))
+	s.WriteString("\n")
+	s.WriteString(mutedStyle.Render(fmt.Sprintf("%d forecasts, %d scored steps", report.Forecasts, report.Samples)))
+	s.WriteString("\n\n")
+
+	header := fmt.Sprintf("%-14s  %6s  %9s  %9s", "Lead Time", "N", "MAPE P50", "P10-P90")
+	for _, q := range report.Quantiles {
+		header += fmt.Sprintf("  %9s", "Loss "+strings.ToUpper(q))
+	}
+	s.WriteString(header)
+	s.WriteString("\n")
+	s.WriteString(strings.Repeat("─", max(a.width-4, len(header))))
+	s.WriteString("\n")
+
+	for _, bucket := range report.Buckets {
+		line := fmt.Sprintf("%-14s  %6d  %9s  %9s",
+			formatLeadRange(bucket.From, bucket.To),
+			bucket.Samples,
+			formatPercent(bucket.MAPE),
+			formatPercent(bucket.Coverage),
+		)
+		for _, q := range report.Quantiles {
+			loss, ok := bucket.Pinball[q]
+			if !ok {
+				line += fmt.Sprintf("  %9s", "-")
+				continue
+			}
+			line += fmt.Sprintf("  %9.2f", loss)
+		}
+
+		if leadTime >= bucket.From && leadTime < bucket.To {
+			s.WriteString(selectedStyle.Render(line + "  ← lead time"))
+		} else {
+			s.WriteString(line)
+		}
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(mutedStyle.Render("MAPE: mean absolute % error of P50. P10-P90: share of actuals inside the band (ideal 80%). Loss: mean pinball loss, lower is better."))
+
+	return s.String()
+}
+
+func formatLeadRange(from, to time.Duration) string {
+	return fmt.Sprintf("%s to %s", formatTimeOffset(from), formatTimeOffset(to))
+}
+
+func formatPercent(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", v*100)
+}
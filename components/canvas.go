@@ -0,0 +1,490 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Braille cells hold a 2x4 grid of dots. brailleDots maps a dot's column and
+// row within the cell to its bit in the U+2800 block.
+const brailleBase = 0x2800
+
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// Layout of the axes drawn around a canvas.
+const (
+	yAxisLabelWidth = 6
+	yAxisWidth      = yAxisLabelWidth + 1 // Label and "┤"
+	minTickSpacing  = 8                   // Columns between x-axis tick labels
+)
+
+// DefaultYLabels is the number of y-axis labels a chart shows unless set.
+const DefaultYLabels = 3
+
+var (
+	bandColor   = lipgloss.Color("241")
+	cursorColor = lipgloss.Color("241")
+)
+
+// bandGlyphs shade bands by how many are nested in a cell, lightest first.
+var bandGlyphs = []rune{'░', '▒', '▓'}
+
+// canvasCell is one terminal cell of a canvas.
+type canvasCell struct {
+	dots  rune
+	glyph rune // Marker drawn instead of the dots
+	rule  bool
+	band  int // Depth of the nested bands covering the cell
+	color lipgloss.Color
+}
+
+// Canvas plots series onto terminal cells using braille dots, so each cell
+// holds 2x4 points and lines stay connected on steep slopes. Series are
+// indexed by step and stretched across the full width; values are scaled
+// between the canvas's y range.
+type Canvas struct {
+	cols, rows int
+	yMin, yMax float64
+	cells      [][]canvasCell
+	cursor     int // Column of the vertical cursor, or -1
+	bands      int // Bands drawn so far
+	ruleColor  lipgloss.Color
+	yFormat    func(float64) string
+}
+
+// NewCanvas creates a canvas of cols by rows cells plotting values between
+// yMin and yMax.
+func NewCanvas(cols, rows int, yMin, yMax float64) *Canvas {
+	cols = max(cols, 1)
+	rows = max(rows, 1)
+	if yMax <= yMin {
+		yMax = yMin + 1
+	}
+
+	cells := make([][]canvasCell, rows)
+	for i := range cells {
+		cells[i] = make([]canvasCell, cols)
+	}
+	return &Canvas{cols: cols, rows: rows, yMin: yMin, yMax: yMax, cells: cells, cursor: -1, yFormat: formatAxisValue}
+}
+
+// SetYFormat sets how y-axis labels are formatted. Labels should be
+// yAxisLabelWidth wide.
+func (c *Canvas) SetYFormat(format func(float64) string) {
+	c.yFormat = format
+}
+
+// Cols returns the canvas width in cells.
+func (c *Canvas) Cols() int {
+	return c.cols
+}
+
+// Column returns the cell column of step i in a series of n steps.
+func (c *Canvas) Column(i, n int) int {
+	return round(c.dotX(i, n)) / 2
+}
+
+// Step returns the step of a series of n steps nearest to cell column col.
+func (c *Canvas) Step(col, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	// Measure from the middle of the cell's two dot columns
+	step := round((float64(col*2) + 0.5) / float64(max(c.cols*2-1, 1)) * float64(n-1))
+	return min(max(step, 0), n-1)
+}
+
+// Cursor draws a vertical line through step i of a series of n steps.
+func (c *Canvas) Cursor(i, n int) {
+	c.cursor = c.Column(i, n)
+}
+
+// dotX returns the dot column of step i in a series of n steps.
+func (c *Canvas) dotX(i, n int) float64 {
+	if n <= 1 {
+		return 0
+	}
+	return float64(i) / float64(n-1) * float64(c.cols*2-1)
+}
+
+// dotY returns the dot row of value v, counted from the top.
+func (c *Canvas) dotY(v float64) float64 {
+	height := float64(c.rows*4 - 1)
+	return height - (v-c.yMin)/(c.yMax-c.yMin)*height
+}
+
+// set turns on the dot at (x, y), ignoring dots outside the canvas.
+func (c *Canvas) set(x, y int, color lipgloss.Color) {
+	if x < 0 || y < 0 || x >= c.cols*2 || y >= c.rows*4 {
+		return
+	}
+	cell := &c.cells[y/4][x/2]
+	cell.dots |= brailleDots[x%2][y%4]
+	cell.color = color
+}
+
+// line draws a line between two dots.
+func (c *Canvas) line(x0, y0, x1, y1 int, color lipgloss.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		c.set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += sx
+		} else {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Line plots values as a connected line. NaN values break the line.
+func (c *Canvas) Line(values []float64, color lipgloss.Color) {
+	n := len(values)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		x, y := round(c.dotX(i, n)), round(c.dotY(v))
+		if i+1 < n && !math.IsNaN(values[i+1]) {
+			c.line(x, y, round(c.dotX(i+1, n)), round(c.dotY(values[i+1])), color)
+		} else {
+			c.set(x, y, color)
+		}
+	}
+}
+
+// Steps plots values as a step line: each value holds until the next step,
+// where the line jumps vertically.
+func (c *Canvas) Steps(values []float64, color lipgloss.Color) {
+	n := len(values)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		x, y := round(c.dotX(i, n)), round(c.dotY(v))
+		if i+1 < n && !math.IsNaN(values[i+1]) {
+			next := round(c.dotX(i+1, n))
+			c.line(x, y, next, y, color)
+			c.line(next, y, next, round(c.dotY(values[i+1])), color)
+		} else {
+			c.set(x, y, color)
+		}
+	}
+}
+
+// Rule draws a dashed horizontal line at value v behind the plotted series.
+func (c *Canvas) Rule(v float64, color lipgloss.Color) {
+	y := round(c.dotY(v))
+	if y < 0 || y >= c.rows*4 {
+		return
+	}
+	for col := range c.cols {
+		c.cells[y/4][col].rule = true
+	}
+	c.ruleColor = color
+}
+
+// Band shades the cells between lower and upper. Each band drawn shades
+// darker than the last, so nested bands drawn widest first show their
+// depth. Lines drawn over a band take precedence.
+func (c *Canvas) Band(lower, upper []float64) {
+	n := min(len(lower), len(upper))
+	if n == 0 {
+		return
+	}
+	c.bands++
+
+	for col := range c.cols {
+		top, bottom := math.Inf(1), math.Inf(-1)
+		for x := col * 2; x <= col*2+1; x++ {
+			lo, okLo := interpolate(lower[:n], c.stepAt(x, n))
+			hi, okHi := interpolate(upper[:n], c.stepAt(x, n))
+			if !okLo || !okHi {
+				continue
+			}
+			top = math.Min(top, c.dotY(math.Max(lo, hi)))
+			bottom = math.Max(bottom, c.dotY(math.Min(lo, hi)))
+		}
+		if math.IsInf(top, 0) {
+			continue
+		}
+
+		first := max(round(top)/4, 0)
+		last := min(round(bottom)/4, c.rows-1)
+		for row := first; row <= last; row++ {
+			c.cells[row][col].band = c.bands
+		}
+	}
+}
+
+// Marks draws glyph in the cell of every value that is not NaN.
+func (c *Canvas) Marks(values []float64, glyph rune, color lipgloss.Color) {
+	n := len(values)
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		x, y := round(c.dotX(i, n)), round(c.dotY(v))
+		if x < 0 || y < 0 || x >= c.cols*2 || y >= c.rows*4 {
+			continue
+		}
+		cell := &c.cells[y/4][x/2]
+		cell.glyph = glyph
+		cell.color = color
+	}
+}
+
+// stepAt returns the fractional step index under dot column x for a series
+// of n steps.
+func (c *Canvas) stepAt(x, n int) float64 {
+	if c.cols*2 <= 1 {
+		return 0
+	}
+	return float64(x) / float64(c.cols*2-1) * float64(n-1)
+}
+
+// Rows renders the canvas, top row first.
+func (c *Canvas) Rows() []string {
+	lines := make([]string, c.rows)
+	for r, cells := range c.cells {
+		var b strings.Builder
+		var run strings.Builder
+		var runColor lipgloss.Color
+
+		flush := func() {
+			if run.Len() == 0 {
+				return
+			}
+			if runColor == "" {
+				b.WriteString(run.String())
+			} else {
+				b.WriteString(lipgloss.NewStyle().Foreground(runColor).Render(run.String()))
+			}
+			run.Reset()
+		}
+
+		for col, cell := range cells {
+			char, color := ' ', lipgloss.Color("")
+			switch {
+			case cell.glyph != 0:
+				char, color = cell.glyph, cell.color
+			case cell.dots != 0:
+				char, color = brailleBase+cell.dots, cell.color
+			case col == c.cursor:
+				char, color = '│', cursorColor
+			case cell.rule:
+				char, color = '┄', c.ruleColor
+			case cell.band > 0:
+				char, color = bandGlyphs[min(cell.band, len(bandGlyphs))-1], bandColor
+			}
+
+			if color != runColor {
+				flush()
+				runColor = color
+			}
+			run.WriteRune(char)
+		}
+		flush()
+		lines[r] = b.String()
+	}
+	return lines
+}
+
+// Frame draws the canvas behind a y-axis with yLabels value labels and an
+// x-axis with time ticks for offsets from to to.
+func (c *Canvas) Frame(yLabels int, from, to time.Duration) []string {
+	labelRows := yLabelRows(c.rows, yLabels)
+	rows := c.Rows()
+
+	lines := make([]string, 0, len(rows)+2)
+	for r, row := range rows {
+		label := strings.Repeat(" ", yAxisLabelWidth)
+		if labelRows[r] {
+			v := c.yMax - float64(r)/float64(max(c.rows-1, 1))*(c.yMax-c.yMin)
+			label = c.yFormat(v)
+		}
+		lines = append(lines, label+"┤"+row)
+	}
+
+	axis, labels := c.timeAxis(from, to)
+	pad := strings.Repeat(" ", yAxisLabelWidth)
+	lines = append(lines, pad+"└"+axis, pad+" "+labels)
+	return lines
+}
+
+// timeAxis returns the x-axis line with tick marks and the tick labels below
+// it, for a canvas covering offsets from to to.
+func (c *Canvas) timeAxis(from, to time.Duration) (string, string) {
+	axis := []rune(strings.Repeat("─", c.cols))
+	labels := []rune(strings.Repeat(" ", c.cols))
+	span := to - from
+
+	next := 0 // First column free for a label
+	for _, offset := range timeTicks(from, to, c.cols) {
+		col := 0
+		if span > 0 {
+			col = round(float64(offset-from) / float64(span) * float64(c.cols-1))
+		}
+
+		label := []rune(formatOffset(offset))
+		start := col - len(label)/2
+		if col == 0 {
+			start = col
+		}
+		start = min(max(start, 0), c.cols-len(label))
+		if start < next {
+			continue
+		}
+
+		axis[col] = '┬'
+		copy(labels[start:], label)
+		next = start + len(label) + 1
+	}
+
+	if c.cursor >= 0 && c.cursor < c.cols {
+		if axis[c.cursor] == '┬' {
+			axis[c.cursor] = '┼'
+		} else {
+			axis[c.cursor] = '┴'
+		}
+	}
+
+	return string(axis), strings.TrimRight(string(labels), " ")
+}
+
+// tickSteps are the candidate spacings between x-axis ticks.
+var tickSteps = []time.Duration{
+	time.Minute, 2 * time.Minute, 5 * time.Minute, 10 * time.Minute,
+	15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour,
+	3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// timeTicks returns tick offsets between from and to, at multiples of the
+// smallest step that leaves room for a label at each tick.
+func timeTicks(from, to time.Duration, cols int) []time.Duration {
+	span := to - from
+	if span <= 0 || cols <= 1 {
+		return []time.Duration{from}
+	}
+
+	step := tickSteps[len(tickSteps)-1]
+	for _, s := range tickSteps {
+		if float64(s)/float64(span)*float64(cols-1) >= minTickSpacing {
+			step = s
+			break
+		}
+	}
+
+	var ticks []time.Duration
+	for offset := (from + step - 1) / step * step; offset <= to; offset += step {
+		ticks = append(ticks, offset)
+	}
+	return ticks
+}
+
+// formatOffset formats a tick offset as "Now", "+15m", "+2h" or "+1h30m".
+func formatOffset(d time.Duration) string {
+	switch {
+	case d == 0:
+		return "Now"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("+%dh", d/time.Hour)
+	case d > time.Hour:
+		return fmt.Sprintf("+%dh%dm", d/time.Hour, d%time.Hour/time.Minute)
+	default:
+		return fmt.Sprintf("+%dm", d/time.Minute)
+	}
+}
+
+// yLabelRows picks n rows, spread evenly from top to bottom, to label.
+func yLabelRows(rows, n int) []bool {
+	labelled := make([]bool, rows)
+	n = min(n, rows)
+	if n <= 0 {
+		return labelled
+	}
+	if n == 1 {
+		labelled[0] = true
+		return labelled
+	}
+	for i := range n {
+		labelled[round(float64(i)*float64(rows-1)/float64(n-1))] = true
+	}
+	return labelled
+}
+
+// axisUnits are the suffixes y-axis labels switch to as values grow.
+var axisUnits = []struct {
+	scale  float64
+	suffix string
+}{{1, ""}, {1e3, "k"}, {1e6, "M"}, {1e9, "G"}, {1e12, "T"}}
+
+// formatAxisValue formats a y-axis value to fit the label width. Values from
+// 10k use a suffix, and a label that would still be too wide drops its
+// decimal or moves up to the next suffix.
+func formatAxisValue(v float64) string {
+	start := 0
+	switch mag := math.Abs(v); {
+	case math.IsInf(v, 0) || math.IsNaN(v):
+		return fmt.Sprintf("%*s", yAxisLabelWidth, strconv.FormatFloat(v, 'f', -1, 64))
+	case mag >= 1e6:
+		start = 2
+	case mag >= 1e4:
+		start = 1
+	}
+	for _, u := range axisUnits[start:] {
+		for _, prec := range []int{1, 0} {
+			if s := strconv.FormatFloat(v/u.scale, 'f', prec, 64) + u.suffix; len(s) <= yAxisLabelWidth {
+				return fmt.Sprintf("%*s", yAxisLabelWidth, s)
+			}
+		}
+	}
+	// Past the largest suffix, "-1e308" is as wide as a label can get
+	return fmt.Sprintf("%*s", yAxisLabelWidth, strings.Replace(strconv.FormatFloat(v, 'e', 0, 64), "e+", "e", 1))
+}
+
+// interpolate returns the linearly interpolated value at fractional index f.
+func interpolate(values []float64, f float64) (float64, bool) {
+	i := int(f)
+	if i >= len(values)-1 {
+		v := values[len(values)-1]
+		return v, !math.IsNaN(v)
+	}
+	a, b := values[i], values[i+1]
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	return a + (b-a)*(f-float64(i)), true
+}
+
+func round(f float64) int {
+	return int(math.Round(f))
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
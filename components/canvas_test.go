@@ -0,0 +1,134 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatAxisValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{0, "   0.0"},
+		{12.34, "  12.3"},
+		{-12.34, " -12.3"},
+		{9999.9, "9999.9"},
+		{-1234.5, " -1234"},
+		{12345, " 12.3k"},
+		{-12345, "-12.3k"},
+		{999950, " 1000k"},
+		{-999950, "-1000k"},
+		{1234567, "  1.2M"},
+		{-123456789, " -123M"},
+		{-1234567890, "-1235M"},
+		{-12345678901, "-12.3G"},
+		{1e15, " 1000T"},
+		{-1e300, "-1e300"},
+		{math.Inf(1), "  +Inf"},
+		{math.NaN(), "   NaN"},
+	}
+
+	for _, tt := range tests {
+		if got := formatAxisValue(tt.value); got != tt.want {
+			t.Errorf("formatAxisValue(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFrameLabelWidth(t *testing.T) {
+	for _, yMax := range []float64{1e6 - 1, -1, 1e20} {
+		canvas := NewCanvas(20, 4, -1e6, yMax)
+		for _, line := range canvas.Frame(4, 0, 0) {
+			if r := []rune(line); len(r) <= yAxisLabelWidth || (r[yAxisLabelWidth] != '┤' && r[yAxisLabelWidth] != '└' && r[yAxisLabelWidth] != ' ') {
+				t.Errorf("yMax %v: axis not at column %d in %q", yMax, yAxisLabelWidth, line)
+			}
+		}
+	}
+}
+
+func TestCanvasColumnStep(t *testing.T) {
+	canvas := NewCanvas(20, 4, 0, 1)
+
+	// Every step maps to a column that maps back to it
+	for _, n := range []int{2, 11, 20} {
+		for i := range n {
+			col := canvas.Column(i, n)
+			if col < 0 || col >= canvas.Cols() {
+				t.Fatalf("Column(%d, %d) = %d, outside the %d columns", i, n, col, canvas.Cols())
+			}
+			if got := canvas.Step(col, n); got != i {
+				t.Errorf("Step(Column(%d, %d)) = %d, want %d", i, n, got, i)
+			}
+		}
+	}
+
+	if got := canvas.Column(10, 11); got != 19 {
+		t.Errorf("Column of the last step = %d, want the last column 19", got)
+	}
+	if got := canvas.Step(-5, 11); got != 0 {
+		t.Errorf("Step left of the canvas = %d, want 0", got)
+	}
+	if got := canvas.Step(50, 11); got != 10 {
+		t.Errorf("Step right of the canvas = %d, want 10", got)
+	}
+}
+
+func TestCanvasLine(t *testing.T) {
+	canvas := NewCanvas(4, 2, 0, 10)
+	canvas.Line([]float64{0, 10}, "")
+	rows := canvas.Rows()
+
+	// A rising line starts in the bottom-left cell and ends in the top-right
+	if r := []rune(rows[1]); r[0] == ' ' || r[3] != ' ' {
+		t.Errorf("bottom row = %q, want dots on the left only", rows[1])
+	}
+	if r := []rune(rows[0]); r[0] != ' ' || r[3] == ' ' {
+		t.Errorf("top row = %q, want dots on the right only", rows[0])
+	}
+}
+
+func TestYLabelRows(t *testing.T) {
+	tests := []struct {
+		rows, n int
+		want    []int
+	}{
+		{rows: 9, n: 3, want: []int{0, 4, 8}},
+		{rows: 9, n: 1, want: []int{0}},
+		{rows: 3, n: 5, want: []int{0, 1, 2}},
+		{rows: 9, n: 0},
+	}
+
+	for _, tt := range tests {
+		var got []int
+		for r, labelled := range yLabelRows(tt.rows, tt.n) {
+			if labelled {
+				got = append(got, r)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("yLabelRows(%d, %d) = %v, want %v", tt.rows, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTimeTicks(t *testing.T) {
+	tests := []struct {
+		from, to time.Duration
+		cols     int
+		want     string
+	}{
+		{0, 30 * time.Minute, 80, "[0s 5m0s 10m0s 15m0s 20m0s 25m0s 30m0s]"},
+		{0, 30 * time.Minute, 20, "[0s 15m0s 30m0s]"},
+		{4 * time.Minute, 18 * time.Minute, 80, "[4m0s 6m0s 8m0s 10m0s 12m0s 14m0s 16m0s 18m0s]"},
+		{0, 0, 80, "[0s]"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprint(timeTicks(tt.from, tt.to, tt.cols)); got != tt.want {
+			t.Errorf("timeTicks(%v, %v, %d) = %s, want %s", tt.from, tt.to, tt.cols, got, tt.want)
+		}
+	}
+}
@@ -40,7 +40,7 @@ func (h *Help) Render() string {
 		{"W", "Jump to sidebar (workload list)"},
 		{"M", "Jump to main panel"},
 		{"", ""},
-		{"1-4", "Jump to tab (Charts/Tables/Config/Logs)"},
+		{"1-5", "Jump to tab (Charts/Tables/Config/Logs/Accuracy)"},
 		{"H, L or ←, →", "Navigate tabs left/right"},
 		{"J, K or ↑, ↓", "Scroll content up/down"},
 		{"G", "Jump to top of scrollable content"},
@@ -50,6 +50,17 @@ func (h *Help) Render() string {
 		{"SPACE", "Toggle between live and paused modes"},
 		{"R", "Manual refresh (fetch latest data)"},
 		{"Ctrl+R", "Retry last failed request"},
+		{"S", "Toggle snapshot scrubbing (Charts/Tables)"},
+		{"←, →", "Step through earlier snapshots while scrubbing"},
+		{"Enter (Charts)", "Start/stop moving the chart cursor, which sets the lead time"},
+		{"←, → (cursor)", "Move the chart cursor; Home/End jump to the first/last step"},
+		{"Click", "Place the chart cursor under the mouse"},
+		{"Z / Shift+Z", "Zoom the chart in/out around the cursor"},
+		{"Shift+←, →", "Pan the zoomed chart"},
+		{"P", "Highlight the next quantile on the chart"},
+		{"↑, ↓ (Tables)", "Move the row cursor; Enter sets the lead time"},
+		{"V (Tables)", "Show/hide columns and pick the sort column"},
+		{"/ (Tables)", "Jump to a time (09:45), offset (+15m) or replica count"},
 		{"", ""},
 		{"C", "Copy current tab content to clipboard"},
 		{"E", "Export current tab content to file"},
@@ -57,23 +68,36 @@ func (h *Help) Render() string {
 		{"+/=", "Increase refresh interval (slower)"},
 		{"-/_", "Decrease refresh interval (faster)"},
 		{"T", "Toggle theme (dark/light)"},
+		{"X", "Switch connection context"},
+		{"O", "Toggle multi-workload overview grid"},
+		{"", ""},
+		{", / .", "Step back/forward one record (replay)"},
+		{"< / >", "Seek 1 minute back/forward (replay)"},
+		{"+ / -", "Double/halve playback speed (replay)"},
 		{"", ""},
 		{"[", "Toggle sidebar collapse"},
 		{"]", "Toggle bottom panel collapse"},
 		{"B", "Cycle bottom panel mode (Logs/Metrics/Events/Info)"},
+		{"/ (Metrics)", "Filter scaler metrics by name or label=value"},
 		{"", ""},
 		{"/", "Filter workloads in sidebar"},
-		{"Enter", "Select workload (in sidebar)"},
+		{"Enter", "Select workload (sidebar or overview)"},
 		{"Esc", "Clear error / Close help / Exit filter"},
 		{"", ""},
 		{"H", "Toggle this help screen"},
 		{"Q or Ctrl+C", "Quit the application"},
 	}
 
+	// Keys may contain multi-byte arrows, so pad by display width
+	keyWidth := 0
+	for _, sc := range shortcuts {
+		keyWidth = max(keyWidth, lipgloss.Width(sc.key))
+	}
+
 	for _, sc := range shortcuts {
 		s.WriteString("  ")
 		s.WriteString(keyStyle.Render(sc.key))
-		s.WriteString(strings.Repeat(" ", 12-len(sc.key)))
+		s.WriteString(strings.Repeat(" ", keyWidth+1-lipgloss.Width(sc.key)))
 		s.WriteString(descStyle.Render(sc.desc))
 		s.WriteString("\n")
 	}
@@ -87,10 +111,11 @@ func (h *Help) Render() string {
 		desc string
 	}{
 		{"Sidebar", "Interactive workload list with health indicators"},
-		{"Main Panel - Charts", "Quantile forecast visualization (P10/P50/P90)"},
-		{"Main Panel - Tables", "Replica scaling decisions with lead time"},
+		{"Main Panel - Charts", "Every forecast quantile over nested bands, with actuals"},
+		{"Main Panel - Tables", "Every forecast step with quantiles, replica changes and forecast error"},
 		{"Main Panel - Config", "Workload and scaler configuration details"},
 		{"Main Panel - Logs", "Forecast and scaler event logs"},
+		{"Main Panel - Accuracy", "MAPE, P10-P90 coverage and pinball loss per lead time"},
 		{"Bottom Panel", "Logs, metrics, events, and system info (press B to cycle)"},
 	}
 
@@ -106,10 +131,7 @@ func (h *Help) Render() string {
 	s.WriteString("\n\n")
 	s.WriteString(descStyle.Render("Config file: ~/.config/kedastral-tui/config.json"))
 	s.WriteString("\n")
-	s.WriteString(descStyle.Render("Override with flags: --forecaster-url, --scaler-url, --workload"))
-	s.WriteString("\n\n")
-
-	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("Press H or any key to close this help screen"))
+	s.WriteString(descStyle.Render("Override with flags: --context, --forecaster-url, --scaler-url, --workload"))
 
 	return s.String()
 }
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	miniMapInside  = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	miniMapOutside = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+)
+
+// renderMiniMap draws every step of values as a sparkline cols wide, with
+// the steps inside w highlighted and bracketed on the line below, aligned
+// with the chart's plot.
+func renderMiniMap(values []float64, w chartWindow, cols int) []string {
+	n := len(values)
+	if n == 0 || cols <= 0 {
+		return nil
+	}
+
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			minVal = math.Min(minVal, v)
+			maxVal = math.Max(maxVal, v)
+		}
+	}
+
+	// Runs of columns on the same side of the window share a style
+	var spark, bracket strings.Builder
+	var sparkRun, bracketRun []rune
+	inside := false
+	flush := func() {
+		if len(sparkRun) == 0 {
+			return
+		}
+		style := miniMapOutside
+		if inside {
+			style = miniMapInside
+		}
+		spark.WriteString(style.Render(string(sparkRun)))
+		bracket.WriteString(style.Render(string(bracketRun)))
+		sparkRun, bracketRun = sparkRun[:0], bracketRun[:0]
+	}
+
+	for col := range cols {
+		i := 0
+		if cols > 1 {
+			i = round(float64(col) / float64(cols-1) * float64(n-1))
+		}
+
+		if w.contains(i) != inside {
+			flush()
+			inside = w.contains(i)
+		}
+
+		char := ' '
+		if v := values[i]; !math.IsNaN(v) {
+			level := 0
+			if maxVal > minVal {
+				level = int((v - minVal) / (maxVal - minVal) * float64(len(sparkBlocks)-1))
+			}
+			char = sparkBlocks[level]
+		}
+		sparkRun = append(sparkRun, char)
+
+		if inside {
+			bracketRun = append(bracketRun, '━')
+		} else {
+			bracketRun = append(bracketRun, '─')
+		}
+	}
+	flush()
+
+	return []string{
+		fmt.Sprintf("%*s┤", yAxisLabelWidth, "map") + spark.String(),
+		strings.Repeat(" ", yAxisLabelWidth) + "└" + bracket.String(),
+	}
+}
@@ -4,19 +4,87 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// QuantileChart renders a forecast chart with P10/P50/P90 quantiles.
+// Series colors shared by the forecast charts. Quantiles below the
+// highlighted one are drawn in lowerColor and those above it in upperColor.
+var (
+	lowerColor     = lipgloss.Color("39")
+	highlightColor = lipgloss.Color("42")
+	upperColor     = lipgloss.Color("196")
+	actualColor    = lipgloss.Color("220")
+)
+
+var actualStyle = lipgloss.NewStyle().Foreground(actualColor)
+
+// QuantileChart renders a forecast chart with a line per quantile, shading
+// the range between each symmetric pair of quantiles.
 type QuantileChart struct {
 	width, height int
+	yLabels       int
+	actuals       []float64
+	cursor        int
+	highlight     string
+	zoom
 }
 
 // NewQuantileChart creates a new quantile chart.
 func NewQuantileChart(width, height int) *QuantileChart {
-	return &QuantileChart{width: width, height: height}
+	return &QuantileChart{width: width, height: height, yLabels: DefaultYLabels, cursor: -1}
+}
+
+// SetCursor places a vertical cursor on step i, with a tooltip describing
+// that step. A negative i hides the cursor.
+func (c *QuantileChart) SetCursor(i int) {
+	c.cursor = i
+}
+
+// StepAt returns the step of snapshot under cell (x, y), counted from the
+// chart's top-left corner. It reports false when the cell is outside the
+// plot.
+func (c *QuantileChart) StepAt(snapshot *client.QuantileSnapshotData, x, y int) (int, bool) {
+	if snapshot == nil {
+		return 0, false
+	}
+	steps := len(snapshot.Snapshot.DesiredReplicas)
+	canvas := c.newCanvas(0, 1)
+	col, row := x-yAxisWidth, y-c.plotTop(snapshot)
+	if steps == 0 || col < 0 || col >= canvas.Cols() || row < 0 || row >= canvas.rows {
+		return 0, false
+	}
+	w := c.window(steps)
+	return w.start + canvas.Step(col, w.count), true
+}
+
+// plotTop returns the line the plot starts on, below the title and, for a
+// forecast without quantiles, the warning.
+func (c *QuantileChart) plotTop(snapshot *client.QuantileSnapshotData) int {
+	if snapshot.APIVersion >= 2 && len(snapshot.Snapshot.SortedQuantiles()) > 0 {
+		return 2
+	}
+	return 3
+}
+
+// SetYLabels sets how many values are labelled on the y-axis.
+func (c *QuantileChart) SetYLabels(n int) {
+	c.yLabels = n
+}
+
+// SetHighlight sets the key of the quantile drawn as the highlighted line.
+// Keys the forecast doesn't have highlight the quantile nearest the median.
+func (c *QuantileChart) SetHighlight(key string) {
+	c.highlight = key
+}
+
+// SetActuals sets observed values aligned to the forecast steps. NaN marks
+// steps without an observation.
+func (c *QuantileChart) SetActuals(actuals []float64) {
+	c.actuals = actuals
 }
 
 // Render renders the quantile chart.
@@ -34,129 +102,139 @@ func (c *QuantileChart) Render(snapshot *client.QuantileSnapshotData) string {
 	return c.renderSingleLine(snapshot)
 }
 
-// renderQuantiles renders P10/P50/P90 quantile lines.
+// renderQuantiles renders a line per quantile over nested bands between
+// its symmetric pairs, such as P10-P90 around P25-P75.
 func (c *QuantileChart) renderQuantiles(snapshot *client.QuantileSnapshotData) string {
-	p10, hasP10 := snapshot.Snapshot.Quantiles["p10"]
-	p50, hasP50 := snapshot.Snapshot.Quantiles["p50"]
-	p90, hasP90 := snapshot.Snapshot.Quantiles["p90"]
-
-	if !hasP50 {
-		return c.renderEmpty()
+	quantiles := snapshot.Snapshot.SortedQuantiles()
+	if len(quantiles) == 0 {
+		return c.renderSingleLine(snapshot)
 	}
+	hi := HighlightIndex(quantiles, c.highlight)
+	highlight := quantiles[hi].Values
 
-	// Find global min/max across all quantiles
-	minVal, maxVal := findMinMaxAcross(p10, p50, p90)
+	w := c.window(len(highlight))
 
-	if minVal == maxVal {
-		maxVal = minVal + 1
+	// Find min/max across the visible steps of all quantiles
+	visible := [][]float64{w.slice(c.actuals)}
+	for _, q := range quantiles {
+		visible = append(visible, w.slice(q.Values))
 	}
+	minVal, maxVal := findMinMaxAcross(visible...)
 
-	// Create chart grid
-	chartHeight := c.height - 4 // Reserve space for title and legend
-	chartWidth := c.width - 10  // Reserve space for Y-axis labels
+	canvas := c.newCanvas(minVal, maxVal)
+	pairs := quantilePairs(quantiles)
+	for _, p := range pairs {
+		canvas.Band(w.slice(quantiles[p[0]].Values), w.slice(quantiles[p[1]].Values))
+	}
+	for i, q := range quantiles {
+		if i != hi {
+			canvas.Line(w.slice(q.Values), quantileColor(i, hi))
+		}
+	}
+	canvas.Line(w.slice(highlight), highlightColor)
+	c.markActuals(canvas, w)
+	c.drawCursor(canvas, w)
+
+	names := make([]string, len(quantiles))
+	series := make([]quantileSeries, len(quantiles))
+	for i, q := range quantiles {
+		names[i] = strings.ToUpper(q.Key)
+		series[i] = quantileSeries{names[i], q.Values}
+	}
 
 	var lines []string
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
-	lines = append(lines, titleStyle.Render("Forecast Timeline (P10/P50/P90)"))
+	lines = append(lines, c.title("Forecast Timeline ("+strings.Join(names, "/")+")", snapshot, w, len(highlight)))
 	lines = append(lines, "")
+	lines = append(lines, c.frame(canvas, snapshot, w, highlight)...)
+	if tooltip := c.tooltip(snapshot, series); tooltip != "" {
+		lines = append(lines, tooltip)
+	}
 
-	// Render Y-axis and plot
-	for row := chartHeight; row >= 0; row-- {
-		yVal := minVal + float64(row)/float64(chartHeight)*(maxVal-minVal)
-
-		// Y-axis label (every few rows)
-		yLabel := ""
-		if row == chartHeight || row == chartHeight/2 || row == 0 {
-			yLabel = fmt.Sprintf("%6.1f", yVal)
-		} else {
-			yLabel = "      "
+	// Lines sharing a color share a legend entry
+	var legend []string
+	for i := 0; i < len(quantiles); {
+		color := quantileColor(i, hi)
+		j := i + 1
+		for j < len(quantiles) && quantileColor(j, hi) == color {
+			j++
 		}
+		legend = append(legend, fmt.Sprintf("%s %s",
+			lipgloss.NewStyle().Foreground(color).Render("───"),
+			strings.Join(names[i:j], "/"),
+		))
+		i = j
+	}
+	for depth, p := range pairs {
+		glyph := bandGlyphs[min(depth, len(bandGlyphs)-1)]
+		legend = append(legend, fmt.Sprintf("%s %s-%s",
+			lipgloss.NewStyle().Foreground(bandColor).Render(strings.Repeat(string(glyph), 3)),
+			names[p[0]], names[p[1]],
+		))
+	}
+	if c.hasActuals() {
+		legend = append(legend, fmt.Sprintf("%s Actual", actualStyle.Render("×××")))
+	}
+	lines = append(lines, "")
+	lines = append(lines, wrapParts("Legend: ", legend, c.width))
 
-		// Plot line
-		var plotLine strings.Builder
-		plotLine.WriteString(" ")
-
-		for col := 0; col < chartWidth; col++ {
-			idx := int(float64(col) / float64(chartWidth) * float64(len(p50)-1))
-			if idx >= len(p50) {
-				idx = len(p50) - 1
-			}
-
-			// Check which quantile to plot at this position
-			char := " "
-			style := lipgloss.NewStyle()
+	return strings.Join(lines, "\n")
+}
 
-			// Determine Y position for each quantile
-			p10Y := -1.0
-			if hasP10 && idx < len(p10) {
-				p10Y = (p10[idx] - minVal) / (maxVal - minVal) * float64(chartHeight)
+// HighlightIndex returns the index in quantiles of the quantile key names,
+// matched by level so "p5" finds "p50", or of the quantile nearest the
+// median when key names none of them.
+func HighlightIndex(quantiles []client.Quantile, key string) int {
+	if level, ok := client.QuantileLevel(key); ok {
+		for i, q := range quantiles {
+			if sameLevel(q.Level, level) {
+				return i
 			}
-			p50Y := (p50[idx] - minVal) / (maxVal - minVal) * float64(chartHeight)
-			p90Y := -1.0
-			if hasP90 && idx < len(p90) {
-				p90Y = (p90[idx] - minVal) / (maxVal - minVal) * float64(chartHeight)
-			}
-
-			// Plot character based on which line is closest to current row
-			currentY := float64(row)
-			minDist := 999999.0
-			plotType := ""
+		}
+	}
 
-			if hasP10 && p10Y >= 0 && math.Abs(currentY-p10Y) < minDist {
-				minDist = math.Abs(currentY - p10Y)
-				plotType = "p10"
-			}
-			if math.Abs(currentY-p50Y) < minDist {
-				minDist = math.Abs(currentY - p50Y)
-				plotType = "p50"
-			}
-			if hasP90 && p90Y >= 0 && math.Abs(currentY-p90Y) < minDist {
-				minDist = math.Abs(currentY - p90Y)
-				plotType = "p90"
-			}
+	best := 0
+	for i, q := range quantiles {
+		if math.Abs(q.Level-0.5) < math.Abs(quantiles[best].Level-0.5) {
+			best = i
+		}
+	}
+	return best
+}
 
-			if minDist < 0.5 {
-				switch plotType {
-				case "p10":
-					char = "·"
-					style = style.Foreground(lipgloss.Color("39")) // Cyan
-				case "p50":
-					char = "●"
-					style = style.Foreground(lipgloss.Color("42")) // Green
-				case "p90":
-					char = "■"
-					style = style.Foreground(lipgloss.Color("196")) // Red
-				}
+// quantilePairs returns the indexes of the symmetric pairs of quantiles,
+// such as P10 and P90, widest first.
+func quantilePairs(quantiles []client.Quantile) [][2]int {
+	var pairs [][2]int
+	for i, lower := range quantiles {
+		if lower.Level >= 0.5 {
+			break
+		}
+		for j := len(quantiles) - 1; j > i; j-- {
+			if sameLevel(lower.Level+quantiles[j].Level, 1) {
+				pairs = append(pairs, [2]int{i, j})
+				break
 			}
-
-			plotLine.WriteString(style.Render(char))
 		}
-
-		lines = append(lines, yLabel+"┤"+plotLine.String())
 	}
+	return pairs
+}
 
-	// X-axis
-	xAxis := "       └" + strings.Repeat("─", chartWidth)
-	lines = append(lines, xAxis)
-
-	// X-axis labels
-	xLabels := "        Now" + strings.Repeat(" ", chartWidth-20) + fmt.Sprintf("+%dm", snapshot.Snapshot.HorizonSeconds/60)
-	lines = append(lines, xLabels)
-
-	// Legend
-	p10Style := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
-	p50Style := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
-	p90Style := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
-
-	legend := fmt.Sprintf("Legend: %s P10  %s P50  %s P90",
-		p10Style.Render("···"),
-		p50Style.Render("●●●"),
-		p90Style.Render("■■■"),
-	)
-	lines = append(lines, "")
-	lines = append(lines, legend)
+// quantileColor returns the line color of quantile i when quantile hi is
+// highlighted.
+func quantileColor(i, hi int) lipgloss.Color {
+	switch {
+	case i < hi:
+		return lowerColor
+	case i > hi:
+		return upperColor
+	}
+	return highlightColor
+}
 
-	return strings.Join(lines, "\n")
+// sameLevel reports whether two quantile levels are equal, allowing for
+// rounding in their parsed keys.
+func sameLevel(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
 }
 
 // renderSingleLine renders a single forecast line (API v1 fallback).
@@ -171,91 +249,152 @@ func (c *QuantileChart) renderSingleLine(snapshot *client.QuantileSnapshotData)
 		}
 	}
 
-	minVal, maxVal := findMinMaxQuantile(values)
-	if minVal == maxVal {
-		maxVal = minVal + 1
-	}
+	w := c.window(len(values))
+	minVal, maxVal := findMinMaxAcross(w.slice(values), w.slice(c.actuals))
 
-	chartHeight := c.height - 4
-	chartWidth := c.width - 10
+	canvas := c.newCanvas(minVal, maxVal)
+	canvas.Line(w.slice(values), highlightColor)
+	c.markActuals(canvas, w)
+	c.drawCursor(canvas, w)
 
 	var lines []string
-	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
 	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
 
-	lines = append(lines, titleStyle.Render("Forecast Timeline"))
+	lines = append(lines, c.title("Forecast Timeline", snapshot, w, len(values)))
 	lines = append(lines, warningStyle.Render("⚠ Quantiles unavailable. Showing single-point forecast."))
 	lines = append(lines, "")
+	lines = append(lines, c.frame(canvas, snapshot, w, values)...)
+	if tooltip := c.tooltip(snapshot, []quantileSeries{{"Forecast", values}}); tooltip != "" {
+		lines = append(lines, tooltip)
+	}
 
-	// Render Y-axis and plot
-	for row := chartHeight; row >= 0; row-- {
-		yVal := minVal + float64(row)/float64(chartHeight)*(maxVal-minVal)
+	if c.hasActuals() {
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("Legend: %s Forecast  %s Actual",
+			lipgloss.NewStyle().Foreground(highlightColor).Render("───"),
+			actualStyle.Render("×××"),
+		))
+	}
 
-		yLabel := ""
-		if row == chartHeight || row == chartHeight/2 || row == 0 {
-			yLabel = fmt.Sprintf("%6.1f", yVal)
-		} else {
-			yLabel = "      "
-		}
+	return strings.Join(lines, "\n")
+}
 
-		var plotLine strings.Builder
-		plotLine.WriteString(" ")
+// newCanvas creates a canvas filling the chart below its title and above
+// its axis and legend.
+func (c *QuantileChart) newCanvas(minVal, maxVal float64) *Canvas {
+	rows := max(c.height-4, 2)
+	cols := max(c.width-yAxisWidth-2, 10)
+	return NewCanvas(cols, rows, minVal, maxVal)
+}
 
-		for col := 0; col < chartWidth; col++ {
-			idx := int(float64(col) / float64(chartWidth) * float64(len(values)-1))
-			if idx >= len(values) {
-				idx = len(values) - 1
-			}
+// title renders the chart title, noting the visible range when zoomed.
+func (c *QuantileChart) title(text string, snapshot *client.QuantileSnapshotData, w chartWindow, n int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	if w.count == n {
+		return titleStyle.Render(text)
+	}
 
-			valueY := (values[idx] - minVal) / (maxVal - minVal) * float64(chartHeight)
-			currentY := float64(row)
+	step := snapshot.Snapshot.StepSeconds
+	zoom := fmt.Sprintf("  %s to %s of %s",
+		formatOffset(snapshotSpan(step, w.start+1)),
+		formatOffset(snapshotSpan(step, w.start+w.count)),
+		strings.TrimPrefix(formatOffset(time.Duration(snapshot.Snapshot.HorizonSeconds)*time.Second), "+"),
+	)
+	return titleStyle.Render(text) + lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render(zoom)
+}
 
-			if math.Abs(currentY-valueY) < 0.5 {
-				plotLine.WriteString("●")
-			} else {
-				plotLine.WriteString(" ")
-			}
-		}
+// frame draws the axes around canvas and, when zoomed, a mini-map of
+// values showing where the window sits in the horizon.
+func (c *QuantileChart) frame(canvas *Canvas, snapshot *client.QuantileSnapshotData, w chartWindow, values []float64) []string {
+	step := snapshot.Snapshot.StepSeconds
+	lines := canvas.Frame(c.yLabels, snapshotSpan(step, w.start+1), snapshotSpan(step, w.start+w.count))
+	if w.count < len(values) {
+		lines = append(lines, renderMiniMap(values, w, canvas.Cols())...)
+	}
+	return lines
+}
 
-		lines = append(lines, yLabel+"┤"+plotLine.String())
+// drawCursor draws the cursor when it falls inside the window.
+func (c *QuantileChart) drawCursor(canvas *Canvas, w chartWindow) {
+	if w.contains(c.cursor) {
+		canvas.Cursor(c.cursor-w.start, w.count)
 	}
+}
 
-	// X-axis
-	xAxis := "       └" + strings.Repeat("─", chartWidth)
-	lines = append(lines, xAxis)
+// quantileSeries is a named series listed in the cursor tooltip.
+type quantileSeries struct {
+	name   string
+	values []float64
+}
 
-	xLabels := "        Now" + strings.Repeat(" ", chartWidth-20) + fmt.Sprintf("+%dm", snapshot.Snapshot.HorizonSeconds/60)
-	lines = append(lines, xLabels)
+// tooltip describes the step under the cursor: its time, offset, the value
+// of each series and the desired replicas.
+func (c *QuantileChart) tooltip(snapshot *client.QuantileSnapshotData, series []quantileSeries) string {
+	snap := snapshot.Snapshot
+	if c.cursor < 0 || c.cursor >= len(snap.DesiredReplicas) {
+		return ""
+	}
 
-	return strings.Join(lines, "\n")
-}
+	offset := time.Duration(c.cursor*snap.StepSeconds) * time.Second
+	parts := []string{
+		lipgloss.NewStyle().Bold(true).Render(clock.Local(snap.GeneratedAt.Add(offset)).Format("15:04:05")),
+		formatTimeOffset(offset),
+	}
+	for _, s := range series {
+		if c.cursor < len(s.values) {
+			parts = append(parts, fmt.Sprintf("%s %.1f", s.name, s.values[c.cursor]))
+		}
+	}
+	if c.cursor < len(c.actuals) && !math.IsNaN(c.actuals[c.cursor]) {
+		parts = append(parts, actualStyle.Render(fmt.Sprintf("Actual %.1f", c.actuals[c.cursor])))
+	}
+	parts = append(parts, fmt.Sprintf("Replicas %d", snap.DesiredReplicas[c.cursor]))
 
-// renderEmpty renders an empty chart placeholder.
-func (c *QuantileChart) renderEmpty() string {
-	return lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Render("No forecast data available")
+	return wrapParts("Cursor: ", parts, c.width)
 }
 
-// findMinMaxQuantile finds min and max values in a slice (quantile version).
-func findMinMaxQuantile(values []float64) (float64, float64) {
-	if len(values) == 0 {
-		return 0, 0
+// wrapParts joins parts after label, wrapping onto lines indented under the
+// label when many quantiles don't fit in width.
+func wrapParts(label string, parts []string, width int) string {
+	if len(parts) == 0 {
+		return label
 	}
 
-	minVal := values[0]
-	maxVal := values[0]
-
-	for _, v := range values {
-		if v < minVal {
-			minVal = v
+	lines := []string{label + parts[0]}
+	for _, part := range parts[1:] {
+		last := &lines[len(lines)-1]
+		if lipgloss.Width(*last)+2+lipgloss.Width(part) > width {
+			lines = append(lines, strings.Repeat(" ", lipgloss.Width(label))+part)
+		} else {
+			*last += "  " + part
 		}
-		if v > maxVal {
-			maxVal = v
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markActuals draws observed values inside the window over the forecast
+// lines.
+func (c *QuantileChart) markActuals(canvas *Canvas, w chartWindow) {
+	if c.hasActuals() {
+		canvas.Marks(w.slice(c.actuals), '×', actualColor)
+	}
+}
+
+// hasActuals reports whether at least one observed value is set.
+func (c *QuantileChart) hasActuals() bool {
+	for _, v := range c.actuals {
+		if !math.IsNaN(v) {
+			return true
 		}
 	}
+	return false
+}
 
-	return minVal, maxVal
+// renderEmpty renders an empty chart placeholder.
+func (c *QuantileChart) renderEmpty() string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("241")).
+		Render("No forecast data available")
 }
 
 // findMinMaxAcross finds min and max across multiple quantile slices.
@@ -283,3 +422,8 @@ func findMinMaxAcross(slices ...[]float64) (float64, float64) {
 
 	return minVal, maxVal
 }
+
+// snapshotSpan returns the time covered by n forecast steps.
+func snapshotSpan(stepSeconds, n int) time.Duration {
+	return time.Duration(stepSeconds*max(n-1, 0)) * time.Second
+}
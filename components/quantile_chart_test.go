@@ -0,0 +1,147 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestQuantileChartGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	v2 := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	v1Snapshot := uitest.Snapshot("checkout")
+	v1Snapshot.Quantiles = nil
+	v1 := client.NewQuantileSnapshotData(v1Snapshot, false, 5*time.Minute)
+
+	// The forecaster's full set of quantiles, spread around the checkout ones
+	wideSnapshot := uitest.Snapshot("checkout")
+	p10, p50, p90 := wideSnapshot.Quantiles["p10"], wideSnapshot.Quantiles["p50"], wideSnapshot.Quantiles["p90"]
+	wideSnapshot.Quantiles = map[string][]float64{"p10": p10, "p50": p50, "p90": p90}
+	for key, spread := range map[string]func(i int) float64{
+		"p05": func(i int) float64 { return p10[i] - 0.5*(p50[i]-p10[i]) },
+		"p25": func(i int) float64 { return (p10[i] + p50[i]) / 2 },
+		"p75": func(i int) float64 { return (p50[i] + p90[i]) / 2 },
+		"p95": func(i int) float64 { return p90[i] + 0.5*(p90[i]-p50[i]) },
+	} {
+		values := make([]float64, len(p50))
+		for i := range values {
+			values[i] = math.Round(spread(i))
+		}
+		wideSnapshot.Quantiles[key] = values
+	}
+	wide := client.NewQuantileSnapshotData(wideSnapshot, false, 5*time.Minute)
+
+	actuals := make([]float64, len(v2.Snapshot.Values))
+	for i := range actuals {
+		actuals[i] = math.NaN()
+	}
+	for i := range 8 {
+		actuals[i] = v2.Snapshot.Values[i] * (1 + 0.15*math.Sin(float64(i)))
+	}
+
+	tests := []struct {
+		name      string
+		snapshot  *client.QuantileSnapshotData
+		actuals   []float64
+		yLabels   int
+		highlight string
+		hasCursor bool
+		cursor    int
+		window    [2]int
+	}{
+		{name: "quantiles", snapshot: v2},
+		{name: "actuals", snapshot: v2, actuals: actuals},
+		{name: "v1", snapshot: v1},
+		{name: "wide", snapshot: wide, hasCursor: true, cursor: 6},
+		{name: "highlight", snapshot: wide, highlight: "p75"},
+		{name: "ylabels", snapshot: v2, yLabels: 5},
+		{name: "cursor", snapshot: v2, actuals: actuals, hasCursor: true, cursor: 6},
+		{name: "cursor_first", snapshot: v2, actuals: actuals, hasCursor: true},
+		{name: "zoom", snapshot: v2, actuals: actuals, hasCursor: true, cursor: 6, window: [2]int{4, 8}},
+		{name: "zoom_v1", snapshot: v1, window: [2]int{20, 10}},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		for _, size := range []struct{ width, height int }{{40, 8}, {80, 12}, {120, 16}} {
+			name := fmt.Sprintf("quantile_chart_%s_%dx%d", tt.name, size.width, size.height)
+			t.Run(name, func(t *testing.T) {
+				chart := NewQuantileChart(size.width, size.height)
+				chart.SetActuals(tt.actuals)
+				chart.SetWindow(tt.window[0], tt.window[1])
+				if tt.hasCursor {
+					chart.SetCursor(tt.cursor)
+				}
+				chart.SetHighlight(tt.highlight)
+				if tt.yLabels > 0 {
+					chart.SetYLabels(tt.yLabels)
+				}
+				uitest.Golden(t, name, chart.Render(tt.snapshot))
+			})
+		}
+	}
+}
+
+func TestQuantileChartFits(t *testing.T) {
+	snapshot := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	for _, size := range []struct{ width, height int }{{40, 8}, {80, 12}, {120, 16}} {
+		chart := NewQuantileChart(size.width, size.height)
+		chart.SetCursor(6)
+		for i, line := range strings.Split(chart.Render(snapshot), "\n") {
+			if w := lipgloss.Width(line); w > size.width {
+				t.Errorf("%dx%d: line %d is %d columns wide", size.width, size.height, i+1, w)
+			}
+		}
+	}
+}
+
+func TestQuantileChartStepAt(t *testing.T) {
+	v2 := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+	v1Snapshot := uitest.Snapshot("checkout")
+	v1Snapshot.Quantiles = nil
+	v1 := client.NewQuantileSnapshotData(v1Snapshot, false, 5*time.Minute)
+
+	// At 80x12 the plot is 71 columns right of the y-axis and 8 rows below
+	// the title, with v1 forecasts a row lower under their warning
+	tests := []struct {
+		name     string
+		snapshot *client.QuantileSnapshotData
+		window   [2]int
+		x, y     int
+		want     int
+		ok       bool
+	}{
+		{name: "first step", snapshot: v2, x: 7, y: 2, want: 0, ok: true},
+		{name: "last step", snapshot: v2, x: 77, y: 9, want: 29, ok: true},
+		{name: "middle", snapshot: v2, x: 42, y: 5, want: 15, ok: true},
+		{name: "zoomed", snapshot: v2, window: [2]int{4, 8}, x: 7, y: 2, want: 4, ok: true},
+		{name: "zoomed end", snapshot: v2, window: [2]int{4, 8}, x: 77, y: 2, want: 11, ok: true},
+		{name: "y-axis", snapshot: v2, x: 6, y: 2},
+		{name: "past the plot", snapshot: v2, x: 78, y: 2},
+		{name: "title", snapshot: v2, x: 7, y: 1},
+		{name: "time axis", snapshot: v2, x: 7, y: 10},
+		{name: "v1 warning", snapshot: v1, x: 7, y: 2},
+		{name: "v1 plot", snapshot: v1, x: 7, y: 3, want: 0, ok: true},
+		{name: "no snapshot", x: 7, y: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := NewQuantileChart(80, 12)
+			chart.SetWindow(tt.window[0], tt.window[1])
+			got, ok := chart.StepAt(tt.snapshot, tt.x, tt.y)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("StepAt(%d, %d) = %d, %v; want %d, %v", tt.x, tt.y, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	replicaColor   = lipgloss.Color("39")
+	scaleUpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	scaleDownStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+)
+
+// ReplicaChart renders desired replicas as a step chart with the same
+// x-axis as QuantileChart, so the two line up when stacked at equal width.
+type ReplicaChart struct {
+	width, height int
+	yLabels       int
+	cursor        int
+	current       int
+	zoom
+}
+
+// NewReplicaChart creates a new replica step chart.
+func NewReplicaChart(width, height int) *ReplicaChart {
+	return &ReplicaChart{width: width, height: height, yLabels: DefaultYLabels, cursor: -1}
+}
+
+// SetYLabels sets how many values are labelled on the y-axis.
+func (c *ReplicaChart) SetYLabels(n int) {
+	c.yLabels = n
+}
+
+// SetCursor marks step i, the lead-time selection. A negative i hides the
+// cursor.
+func (c *ReplicaChart) SetCursor(i int) {
+	c.cursor = i
+}
+
+// SetCurrent sets the workload's current replica count, drawn as a dashed
+// line. Zero hides it.
+func (c *ReplicaChart) SetCurrent(replicas int) {
+	c.current = replicas
+}
+
+// Render renders the replica chart.
+func (c *ReplicaChart) Render(snapshot *client.QuantileSnapshotData) string {
+	if snapshot == nil || len(snapshot.Snapshot.DesiredReplicas) == 0 {
+		return ""
+	}
+
+	snap := snapshot.Snapshot
+	desired := make([]float64, len(snap.DesiredReplicas))
+	for i, r := range snap.DesiredReplicas {
+		desired[i] = float64(r)
+	}
+
+	w := c.window(len(desired))
+	visible := w.slice(desired)
+
+	lo, hi := findMinMaxAcross(visible)
+	if c.current > 0 {
+		lo = math.Min(lo, float64(c.current))
+		hi = math.Max(hi, float64(c.current))
+	}
+
+	// Round the range up so every row sits on a whole replica count,
+	// keeping the integer labels exact
+	rows := max(c.height-4, 2)
+	yMin := math.Max(lo-1, 0)
+	yMax := yMin + math.Max(math.Ceil((hi-yMin)/float64(rows-1)), 1)*float64(rows-1)
+
+	canvas := NewCanvas(max(c.width-yAxisWidth-2, 10), rows, yMin, yMax)
+	canvas.SetYFormat(func(v float64) string {
+		if label := fmt.Sprintf("%*d", yAxisLabelWidth, int(math.Round(v))); len(label) == yAxisLabelWidth {
+			return label
+		}
+		return formatAxisValue(v)
+	})
+	if c.current > 0 {
+		canvas.Rule(float64(c.current), lipgloss.Color("241"))
+	}
+	canvas.Steps(visible, replicaColor)
+	if w.contains(c.cursor) {
+		canvas.Cursor(c.cursor-w.start, w.count)
+	}
+
+	var lines []string
+	lines = append(lines, c.title(snapshot))
+
+	frame := canvas.Frame(c.yLabels, snapshotSpan(snap.StepSeconds, w.start+1), snapshotSpan(snap.StepSeconds, w.start+w.count))
+	axis := len(frame) - 2
+	lines = append(lines, frame[:axis]...)
+	lines = append(lines, strings.Repeat(" ", yAxisWidth)+c.deltas(canvas, snap.DesiredReplicas, w))
+	lines = append(lines, frame[axis:]...)
+
+	return strings.Join(lines, "\n")
+}
+
+// title renders the chart title with the replicas at the lead time and the
+// current count.
+func (c *ReplicaChart) title(snapshot *client.QuantileSnapshotData) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	snap := snapshot.Snapshot
+
+	var parts []string
+	if c.cursor >= 0 && c.cursor < len(snap.DesiredReplicas) {
+		offset := time.Duration(c.cursor*snap.StepSeconds) * time.Second
+		parts = append(parts, fmt.Sprintf("%s at %s", pluralReplicas(snap.DesiredReplicas[c.cursor]), formatTimeOffset(offset)))
+	}
+	if c.current > 0 {
+		parts = append(parts, fmt.Sprintf("┄ current %d", c.current))
+	}
+
+	title := titleStyle.Render("Desired Replicas")
+	if len(parts) > 0 {
+		title += lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("  " + strings.Join(parts, "  "))
+	}
+	return title
+}
+
+// deltas returns a row labelling each scale-up and scale-down inside the
+// window with its change, under the step where it happens. Labels that would
+// overlap an earlier one are dropped.
+func (c *ReplicaChart) deltas(canvas *Canvas, desired []int, w chartWindow) string {
+	var b strings.Builder
+	pos := 0
+	for i := w.start + 1; i < w.start+w.count; i++ {
+		delta := desired[i] - desired[i-1]
+		if delta == 0 {
+			continue
+		}
+
+		col := canvas.Column(i-w.start, w.count)
+		if col < pos || col >= canvas.Cols() {
+			continue
+		}
+
+		label, style := fmt.Sprintf("+%d", delta), scaleUpStyle
+		if delta < 0 {
+			label, style = fmt.Sprintf("%d", delta), scaleDownStyle
+		}
+		b.WriteString(strings.Repeat(" ", col-pos))
+		b.WriteString(style.Render(label))
+		pos = col + len(label) + 1
+		b.WriteString(" ")
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// pluralReplicas formats a replica count with its noun.
+func pluralReplicas(n int) string {
+	if n == 1 {
+		return "1 replica"
+	}
+	return fmt.Sprintf("%d replicas", n)
+}
@@ -0,0 +1,124 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+)
+
+func TestReplicaChartGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	snap := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	chart := NewReplicaChart(80, 8)
+	chart.SetCurrent(2)
+	chart.SetCursor(6)
+	uitest.Golden(t, "replica_chart", chart.Render(snap))
+}
+
+// The test forecast's desired replicas are 2, 2, 3 from step 2, 4 from step
+// 7, 3 from step 23 and 2 from step 28.
+func TestReplicaChart(t *testing.T) {
+	snap := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	tests := []struct {
+		name    string
+		cursor  int
+		current int
+		window  [2]int
+		yLabels int
+		title   string
+		labels  []string
+		deltas  []string
+	}{
+		{
+			name:   "steps",
+			cursor: -1,
+			title:  "Desired Replicas",
+			labels: []string{"4", "2", "1"},
+			deltas: []string{"+1", "+1", "-1", "-1"},
+		},
+		{
+			name:    "cursor and current",
+			cursor:  6,
+			current: 2,
+			title:   "Desired Replicas  3 replicas at +6m  ┄ current 2",
+			labels:  []string{"4", "2", "1"},
+			deltas:  []string{"+1", "+1", "-1", "-1"},
+		},
+		{
+			name:    "y labels",
+			cursor:  7,
+			yLabels: 4,
+			title:   "Desired Replicas  4 replicas at +7m",
+			labels:  []string{"4", "3", "2", "1"},
+			deltas:  []string{"+1", "+1", "-1", "-1"},
+		},
+		{
+			name:    "current above the forecast",
+			cursor:  -1,
+			current: 7,
+			title:   "Desired Replicas  ┄ current 7",
+			labels:  []string{"7", "3", "1"},
+			deltas:  []string{"+1", "+1", "-1", "-1"},
+		},
+		{
+			name:   "zoom",
+			cursor: 6,
+			window: [2]int{4, 8},
+			title:  "Desired Replicas  3 replicas at +6m",
+			labels: []string{"5", "3", "2"},
+			deltas: []string{"+1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chart := NewReplicaChart(80, 8)
+			chart.SetCursor(tt.cursor)
+			chart.SetCurrent(tt.current)
+			chart.SetWindow(tt.window[0], tt.window[1])
+			if tt.yLabels > 0 {
+				chart.SetYLabels(tt.yLabels)
+			}
+
+			// A title, 4 plot rows, the deltas, the time axis and its labels
+			lines := strings.Split(chart.Render(snap), "\n")
+			if len(lines) != 8 {
+				t.Fatalf("got %d lines, want 8", len(lines))
+			}
+			if lines[0] != tt.title {
+				t.Errorf("title = %q, want %q", lines[0], tt.title)
+			}
+
+			var labels []string
+			for _, line := range lines[1:5] {
+				if label := strings.TrimSpace(string([]rune(line)[:yAxisLabelWidth])); label != "" {
+					labels = append(labels, label)
+				}
+			}
+			if strings.Join(labels, " ") != strings.Join(tt.labels, " ") {
+				t.Errorf("y labels = %v, want %v", labels, tt.labels)
+			}
+			if got := strings.Fields(lines[5]); strings.Join(got, " ") != strings.Join(tt.deltas, " ") {
+				t.Errorf("deltas = %v, want %v", got, tt.deltas)
+			}
+		})
+	}
+
+	if got := NewReplicaChart(80, 8).Render(nil); got != "" {
+		t.Errorf("Render(nil) = %q, want nothing", got)
+	}
+}
+
+func TestPluralReplicas(t *testing.T) {
+	for n, want := range map[int]string{0: "0 replicas", 1: "1 replica", 4: "4 replicas"} {
+		if got := pluralReplicas(n); got != want {
+			t.Errorf("pluralReplicas(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -3,25 +3,187 @@ package components
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/charmbracelet/lipgloss"
 )
 
-// ReplicaTable renders a table of replica scaling decisions.
-type ReplicaTable struct {
+// TableColumn is a column of the replica table, named by its header. A
+// quantile's column is named by its upper-cased key.
+type TableColumn string
+
+const (
+	ColumnTime     TableColumn = "Time"
+	ColumnOffset   TableColumn = "Offset"
+	ColumnForecast TableColumn = "Forecast" // A v1 forecast's single value
+	ColumnActual   TableColumn = "Actual"
+	ColumnError    TableColumn = "Error"
+	ColumnDesired  TableColumn = "Desired"
+	ColumnDelta    TableColumn = "Δ"
+)
+
+// QuantileColumn returns the column of the quantile with key.
+func QuantileColumn(key string) TableColumn {
+	return TableColumn(strings.ToUpper(key))
+}
+
+// tableColumnLayout is a column's width and alignment. Columns not listed
+// hold forecast values.
+var tableColumnLayout = map[TableColumn]struct {
 	width int
+	right bool
+}{
+	ColumnTime:    {8, false},
+	ColumnOffset:  {7, false},
+	ColumnActual:  {9, true},
+	ColumnError:   {16, false},
+	ColumnDesired: {7, true},
+	ColumnDelta:   {4, true},
+}
+
+func (c TableColumn) String() string {
+	return string(c)
+}
+
+// ColumnSet is a set of table columns.
+type ColumnSet []TableColumn
+
+// Has reports whether c is in the set.
+func (s ColumnSet) Has(c TableColumn) bool {
+	return slices.Contains(s, c)
+}
+
+// Toggle returns a copy of the set with c added or removed.
+func (s ColumnSet) Toggle(c TableColumn) ColumnSet {
+	if i := slices.Index(s, c); i >= 0 {
+		return slices.Delete(slices.Clone(s), i, i+1)
+	}
+	return append(slices.Clone(s), c)
+}
+
+// TableSort orders the table's rows by a column. The zero value keeps the
+// forecast's step order.
+type TableSort struct {
+	Column TableColumn
+	Desc   bool
+}
+
+// ReplicaTable renders a table of replica scaling decisions, one row per
+// forecast step.
+type ReplicaTable struct {
+	width   int
+	actuals []float64
+	hidden  ColumnSet
+	sort    TableSort
+	cursor  int
 }
 
 // NewReplicaTable creates a new replica table component.
 func NewReplicaTable(width int) *ReplicaTable {
-	return &ReplicaTable{width: width}
+	return &ReplicaTable{width: width, cursor: -1}
+}
+
+// SetActuals sets observed values aligned to the forecast steps. NaN marks
+// steps without an observation.
+func (r *ReplicaTable) SetActuals(actuals []float64) {
+	r.actuals = actuals
+}
+
+// SetHidden hides the given columns.
+func (r *ReplicaTable) SetHidden(hidden ColumnSet) {
+	r.hidden = hidden
+}
+
+// SetSort sets the row order.
+func (r *ReplicaTable) SetSort(sort TableSort) {
+	r.sort = sort
+}
+
+// SetCursor highlights the row of step i. A negative i hides the cursor.
+func (r *ReplicaTable) SetCursor(i int) {
+	r.cursor = i
+}
+
+// Columns returns the columns snapshot has data for, in display order:
+// one per quantile of a v2 forecast, and actuals only once they are set.
+func (r *ReplicaTable) Columns(snapshot *client.QuantileSnapshotData) []TableColumn {
+	columns := []TableColumn{ColumnTime, ColumnOffset}
+
+	if quantiles := tableQuantiles(snapshot); len(quantiles) > 0 {
+		for _, q := range quantiles {
+			if c := QuantileColumn(q.Key); !slices.Contains(columns, c) {
+				columns = append(columns, c)
+			}
+		}
+	} else {
+		columns = append(columns, ColumnForecast)
+	}
+
+	if r.actuals != nil {
+		columns = append(columns, ColumnActual, ColumnError)
+	}
+	return append(columns, ColumnDesired, ColumnDelta)
+}
+
+// Order returns the steps of snapshot in the order their rows are shown.
+func (r *ReplicaTable) Order(snapshot *client.QuantileSnapshotData) []int {
+	if snapshot == nil {
+		return nil
+	}
+	quantiles := tableQuantiles(snapshot)
+
+	order := make([]int, len(snapshot.Snapshot.DesiredReplicas))
+	for i := range order {
+		order[i] = i
+	}
+	if r.sort == (TableSort{}) {
+		return order
+	}
+
+	// Steps without a value sort last in either direction, and equal values
+	// keep step order
+	slices.SortStableFunc(order, func(a, b int) int {
+		va, vb := r.value(snapshot, quantiles, r.sort.Column, a), r.value(snapshot, quantiles, r.sort.Column, b)
+		switch {
+		case math.IsNaN(va) && math.IsNaN(vb):
+			return 0
+		case math.IsNaN(va):
+			return 1
+		case math.IsNaN(vb):
+			return -1
+		}
+
+		cmp := 0
+		if va < vb {
+			cmp = -1
+		} else if va > vb {
+			cmp = 1
+		}
+		if r.sort.Desc {
+			cmp = -cmp
+		}
+		return cmp
+	})
+	return order
+}
+
+// CursorLine returns the line of the cursor's row in the rendered table, or
+// -1 when the cursor isn't on a row.
+func (r *ReplicaTable) CursorLine(snapshot *client.QuantileSnapshotData) int {
+	if pos := slices.Index(r.Order(snapshot), r.cursor); pos >= 0 {
+		// Rows follow the title, a blank line, the header and its rule
+		return pos + 4
+	}
+	return -1
 }
 
 // Render renders the replica table.
-func (r *ReplicaTable) Render(snapshot *client.SnapshotData) string {
+func (r *ReplicaTable) Render(snapshot *client.QuantileSnapshotData) string {
 	if snapshot == nil || len(snapshot.Snapshot.DesiredReplicas) == 0 {
 		return "No replica data available"
 	}
@@ -29,55 +191,191 @@ func (r *ReplicaTable) Render(snapshot *client.SnapshotData) string {
 	var s strings.Builder
 
 	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
-	normalStyle := lipgloss.NewStyle()
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+
+	snap := snapshot.Snapshot
+	columns := r.columns(snapshot)
+	quantiles := tableQuantiles(snapshot)
 
 	s.WriteString(headerStyle.Render("REPLICA SCALING DECISIONS"))
+	s.WriteString(mutedStyle.Render(fmt.Sprintf("  %s · %d steps", snap.Metric, len(snap.DesiredReplicas))))
 	s.WriteString("\n\n")
 
-	s.WriteString(fmt.Sprintf("%-10s  %-12s  %-8s  %-10s\n",
-		"Time", "Forecast", "Desired", "Selected"))
-	s.WriteString(strings.Repeat("─", r.width-4))
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		name := c.String()
+		if r.sort != (TableSort{}) && c == r.sort.Column {
+			name += sortArrow(r.sort.Desc)
+		}
+		header[i] = padCell(name, c)
+	}
+	s.WriteString("  " + strings.Join(header, "  "))
+	s.WriteString("\n")
+	s.WriteString(strings.Repeat("─", max(r.width-4, 0)))
 	s.WriteString("\n")
 
-	snap := snapshot.Snapshot
-	stepDuration := time.Duration(snap.StepSeconds) * time.Second
+	for _, i := range r.Order(snapshot) {
+		cells := make([]string, len(columns))
+		for j, c := range columns {
+			cells[j] = padCell(r.cell(snapshot, quantiles, c, i), c)
+		}
+
+		prefix := "  "
+		if i == r.cursor {
+			prefix = "> "
+		}
 
-	maxRows := min(len(snap.DesiredReplicas), 10)
+		var line string
+		switch {
+		case i == r.cursor:
+			line = cursorStyle.Render(prefix + strings.Join(cells, "  ") + leadMarker(i, snapshot))
+		case i == snapshot.LeadTimeIndex:
+			line = selectedStyle.Render(prefix + strings.Join(cells, "  ") + leadMarker(i, snapshot))
+		default:
+			// Only plain rows color their replica change, so row styles
+			// aren't cut short by the cell's reset
+			for j, c := range columns {
+				if c == ColumnDelta {
+					cells[j] = deltaStyle(r.value(snapshot, quantiles, c, i)).Render(cells[j])
+				}
+			}
+			line = prefix + strings.Join(cells, "  ")
+		}
+		s.WriteString(strings.TrimRight(line, " "))
+		s.WriteString("\n")
+	}
 
-	for i := 0; i < maxRows; i++ {
-		timeOffset := stepDuration * time.Duration(i)
-		timeStr := formatTimeOffset(timeOffset)
+	return s.String()
+}
 
-		forecastVal := 0.0
-		if i < len(snap.Values) {
-			forecastVal = snap.Values[i]
+// columns returns the shown columns: those with data that aren't hidden.
+func (r *ReplicaTable) columns(snapshot *client.QuantileSnapshotData) []TableColumn {
+	var columns []TableColumn
+	for _, c := range r.Columns(snapshot) {
+		if !r.hidden.Has(c) {
+			columns = append(columns, c)
 		}
+	}
+	return columns
+}
 
-		replicas := snap.DesiredReplicas[i]
+// tableQuantiles returns the quantiles of a v2 forecast, ordered as their
+// columns are.
+func tableQuantiles(snapshot *client.QuantileSnapshotData) []client.Quantile {
+	if snapshot == nil || snapshot.APIVersion < 2 {
+		return nil
+	}
+	return snapshot.Snapshot.SortedQuantiles()
+}
 
-		isSelected := i == snapshot.LeadTimeIndex
+// value returns the value of column c at step i that rows are sorted by, or
+// NaN when the step has none. Quantile columns read from quantiles, the
+// snapshot's tableQuantiles.
+func (r *ReplicaTable) value(snapshot *client.QuantileSnapshotData, quantiles []client.Quantile, c TableColumn, i int) float64 {
+	snap := snapshot.Snapshot
+	at := func(values []float64) float64 {
+		if i < len(values) {
+			return values[i]
+		}
+		return math.NaN()
+	}
 
-		line := fmt.Sprintf("%-10s  %-12s  %-8d  ",
-			timeStr,
-			fmt.Sprintf("%.1f %s", forecastVal, snap.Metric),
-			replicas,
-		)
+	switch c {
+	case ColumnTime, ColumnOffset:
+		return float64(i)
+	case ColumnForecast:
+		return at(snap.Values)
+	case ColumnActual:
+		return at(r.actuals)
+	case ColumnError:
+		return at(r.actuals) - at(snap.Values)
+	case ColumnDesired:
+		return float64(snap.DesiredReplicas[i])
+	case ColumnDelta:
+		if i == 0 {
+			return 0
+		}
+		return float64(snap.DesiredReplicas[i] - snap.DesiredReplicas[i-1])
+	}
 
-		if isSelected {
-			line += "← SELECTED"
-			s.WriteString(selectedStyle.Render(line))
-		} else {
-			s.WriteString(normalStyle.Render(line))
+	// Keys differing only in case share a column, showing the first
+	if j := slices.IndexFunc(quantiles, func(q client.Quantile) bool { return QuantileColumn(q.Key) == c }); j >= 0 {
+		return at(quantiles[j].Values)
+	}
+	return math.NaN()
+}
+
+// cell formats column c at step i.
+func (r *ReplicaTable) cell(snapshot *client.QuantileSnapshotData, quantiles []client.Quantile, c TableColumn, i int) string {
+	snap := snapshot.Snapshot
+	offset := time.Duration(i*snap.StepSeconds) * time.Second
+
+	switch c {
+	case ColumnTime:
+		return clock.Local(snap.GeneratedAt.Add(offset)).Format("15:04:05")
+	case ColumnOffset:
+		return formatTimeOffset(offset)
+	case ColumnError:
+		actual, forecast := r.value(snapshot, quantiles, ColumnActual, i), r.value(snapshot, quantiles, ColumnForecast, i)
+		if math.IsNaN(actual) || math.IsNaN(forecast) {
+			return "-"
 		}
-		s.WriteString("\n")
+		return formatForecastError(actual, forecast)
+	case ColumnDesired:
+		return fmt.Sprintf("%d", snap.DesiredReplicas[i])
+	case ColumnDelta:
+		if d := int(r.value(snapshot, quantiles, c, i)); d != 0 {
+			return fmt.Sprintf("%+d", d)
+		}
+		return ""
 	}
 
-	if len(snap.DesiredReplicas) > maxRows {
-		s.WriteString(fmt.Sprintf("\n... and %d more steps", len(snap.DesiredReplicas)-maxRows))
+	if v := r.value(snapshot, quantiles, c, i); !math.IsNaN(v) {
+		return fmt.Sprintf("%.1f", v)
 	}
+	return "-"
+}
 
-	return s.String()
+// padCell pads text to column c's width and alignment.
+func padCell(text string, c TableColumn) string {
+	layout, ok := tableColumnLayout[c]
+	if !ok {
+		layout.width, layout.right = 9, true
+	}
+	pad := strings.Repeat(" ", max(layout.width-lipgloss.Width(text), 0))
+	if layout.right {
+		return pad + text
+	}
+	return text + pad
+}
+
+// leadMarker marks the row at the lead time.
+func leadMarker(i int, snapshot *client.QuantileSnapshotData) string {
+	if i == snapshot.LeadTimeIndex {
+		return "  ← SELECTED"
+	}
+	return ""
+}
+
+// deltaStyle colors a replica change: scale-ups green, scale-downs red.
+func deltaStyle(delta float64) lipgloss.Style {
+	switch {
+	case delta > 0:
+		return scaleUpStyle
+	case delta < 0:
+		return scaleDownStyle
+	}
+	return lipgloss.NewStyle()
+}
+
+// sortArrow marks the sorted column's direction.
+func sortArrow(desc bool) string {
+	if desc {
+		return " ▼"
+	}
+	return " ▲"
 }
 
 func formatTimeOffset(d time.Duration) string {
@@ -91,3 +389,13 @@ func formatTimeOffset(d time.Duration) string {
 	}
 	return fmt.Sprintf("+%dm", minutes)
 }
+
+// formatForecastError formats actual minus forecast, with the relative error
+// when the actual value is non-zero.
+func formatForecastError(actual, forecast float64) string {
+	diff := actual - forecast
+	if actual == 0 {
+		return fmt.Sprintf("%+.1f", diff)
+	}
+	return fmt.Sprintf("%+.1f (%+.0f%%)", diff, diff/math.Abs(actual)*100)
+}
@@ -0,0 +1,197 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+)
+
+// testActuals observes the first three steps of the test forecast, whose P50
+// starts at 100, 113 and 126.
+func testActuals(n int) []float64 {
+	actuals := make([]float64, n)
+	for i := range actuals {
+		actuals[i] = math.NaN()
+	}
+	actuals[0], actuals[1], actuals[2] = 98, 131, 140
+	return actuals
+}
+
+func TestReplicaTableGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	snapshot := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+	// The row at the lead time is marked as the selected one
+	lead := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 12*time.Minute)
+	actuals := testActuals(len(snapshot.Snapshot.Values))
+
+	tests := []struct {
+		name      string
+		snapshot  *client.QuantileSnapshotData
+		actuals   []float64
+		sort      TableSort
+		hidden    ColumnSet
+		hasCursor bool
+		cursor    int
+	}{
+		{name: "forecast", snapshot: snapshot},
+		{name: "actuals", snapshot: snapshot, actuals: actuals},
+		{name: "lead_12m", snapshot: lead},
+		{name: "sorted", snapshot: snapshot, actuals: actuals, sort: TableSort{Column: ColumnError, Desc: true}, hasCursor: true, cursor: 2},
+		{name: "cursor_first", snapshot: snapshot, hasCursor: true},
+		{name: "columns", snapshot: snapshot, hidden: ColumnSet{ColumnOffset, QuantileColumn("p10"), QuantileColumn("p90")}},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		for _, width := range []int{40, 80, 120} {
+			name := fmt.Sprintf("replica_table_%s_%d", tt.name, width)
+			t.Run(name, func(t *testing.T) {
+				table := NewReplicaTable(width)
+				table.SetActuals(tt.actuals)
+				table.SetSort(tt.sort)
+				table.SetHidden(tt.hidden)
+				if tt.hasCursor {
+					table.SetCursor(tt.cursor)
+				}
+				uitest.Golden(t, name, table.Render(tt.snapshot))
+			})
+		}
+	}
+}
+
+func TestReplicaTableColumns(t *testing.T) {
+	v2 := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+	v1Snapshot := uitest.Snapshot("checkout")
+	v1Snapshot.Quantiles = nil
+	v1 := client.NewQuantileSnapshotData(v1Snapshot, false, 5*time.Minute)
+
+	tests := []struct {
+		name     string
+		snapshot *client.QuantileSnapshotData
+		actuals  []float64
+		want     string
+	}{
+		{name: "v2", snapshot: v2, want: "[Time Offset P10 P50 P90 Desired Δ]"},
+		{name: "v2 with actuals", snapshot: v2, actuals: testActuals(30), want: "[Time Offset P10 P50 P90 Actual Error Desired Δ]"},
+		{name: "v1", snapshot: v1, want: "[Time Offset Forecast Desired Δ]"},
+		{name: "no forecast", want: "[Time Offset Forecast Desired Δ]"},
+	}
+
+	for _, tt := range tests {
+		table := NewReplicaTable(80)
+		table.SetActuals(tt.actuals)
+		if got := fmt.Sprint(table.Columns(tt.snapshot)); got != tt.want {
+			t.Errorf("%s: Columns = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// The test forecast's desired replicas are 2 at steps 0, 1, 28 and 29, 3 at
+// steps 2 to 6 and 23 to 27, and 4 in between. Its P50 peaks at 220 on
+// steps 14 and 15.
+func TestReplicaTableOrder(t *testing.T) {
+	snapshot := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	tests := []struct {
+		name    string
+		sort    TableSort
+		actuals []float64
+		want    []int // The first rows
+	}{
+		{name: "step order", want: []int{0, 1, 2, 3}},
+		{name: "time descending", sort: TableSort{Column: ColumnTime, Desc: true}, want: []int{29, 28, 27, 26}},
+		{name: "desired, stable", sort: TableSort{Column: ColumnDesired}, want: []int{0, 1, 28, 29, 2, 3}},
+		{name: "change descending", sort: TableSort{Column: ColumnDelta, Desc: true}, want: []int{2, 7, 0, 1}},
+		{name: "quantile descending", sort: TableSort{Column: QuantileColumn("p50"), Desc: true}, want: []int{14, 15}},
+		// Errors are -2, +18 and +14, and steps without one sort last
+		{name: "error descending", sort: TableSort{Column: ColumnError, Desc: true}, actuals: testActuals(30), want: []int{1, 2, 0, 3, 4}},
+		{name: "error ascending", sort: TableSort{Column: ColumnError}, actuals: testActuals(30), want: []int{0, 2, 1, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewReplicaTable(80)
+			table.SetActuals(tt.actuals)
+			table.SetSort(tt.sort)
+			order := table.Order(snapshot)
+			if len(order) != 30 {
+				t.Fatalf("got %d rows, want 30", len(order))
+			}
+			if got := order[:len(tt.want)]; !slices.Equal(got, tt.want) {
+				t.Errorf("first rows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := NewReplicaTable(80).Order(nil); got != nil {
+		t.Errorf("Order(nil) = %v, want nil", got)
+	}
+}
+
+func TestReplicaTableQuantileValues(t *testing.T) {
+	// Keys differing only in case share a column, which always shows the
+	// first key in sorted order
+	snap := uitest.Snapshot("checkout")
+	snap.Quantiles = map[string][]float64{"p10": {1, 2}, "P50": {5, 6}, "p50": {50, 60}, "p90": {9, 10}}
+	snap.DesiredReplicas = []int{1, 2}
+	snapshot := client.NewQuantileSnapshotData(snap, false, 0)
+
+	table := NewReplicaTable(80)
+	if got := fmt.Sprint(table.Columns(snapshot)); got != "[Time Offset P10 P50 P90 Desired Δ]" {
+		t.Fatalf("Columns = %s", got)
+	}
+	quantiles := tableQuantiles(snapshot)
+	for range 20 {
+		if got := table.value(snapshot, quantiles, QuantileColumn("p50"), 1); got != 6 {
+			t.Fatalf("P50 at step 1 = %v, want 6 from the P50 key", got)
+		}
+	}
+	if got := table.value(snapshot, quantiles, QuantileColumn("p25"), 1); !math.IsNaN(got) {
+		t.Errorf("missing quantile = %v, want NaN", got)
+	}
+}
+
+func TestReplicaTableCursorLine(t *testing.T) {
+	snapshot := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), false, 5*time.Minute)
+
+	// Rows start on line 4, under the title, a blank line, the header and
+	// its rule
+	table := NewReplicaTable(80)
+	table.SetCursor(2)
+	if got := table.CursorLine(snapshot); got != 6 {
+		t.Errorf("CursorLine = %d in step order, want 6", got)
+	}
+
+	table.SetSort(TableSort{Column: ColumnTime, Desc: true})
+	if got := table.CursorLine(snapshot); got != 31 {
+		t.Errorf("CursorLine = %d sorted by time descending, want 31", got)
+	}
+
+	table.SetCursor(40)
+	if got := table.CursorLine(snapshot); got != -1 {
+		t.Errorf("CursorLine = %d past the last step, want -1", got)
+	}
+}
+
+func TestFormatForecastError(t *testing.T) {
+	tests := []struct {
+		actual, forecast float64
+		want             string
+	}{
+		{131, 113, "+18.0 (+14%)"},
+		{98, 100, "-2.0 (-2%)"},
+		{0, 5, "-5.0"},
+	}
+
+	for _, tt := range tests {
+		if got := formatForecastError(tt.actual, tt.forecast); got != tt.want {
+			t.Errorf("formatForecastError(%v, %v) = %q, want %q", tt.actual, tt.forecast, got, tt.want)
+		}
+	}
+}
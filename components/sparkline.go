@@ -0,0 +1,54 @@
+package components
+
+import (
+	"math"
+	"strings"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders a series of values as a single line of block characters.
+type Sparkline struct {
+	width int
+}
+
+// NewSparkline creates a sparkline that shows at most width values.
+func NewSparkline(width int) *Sparkline {
+	return &Sparkline{width: width}
+}
+
+// Render renders the most recent values scaled between their min and max.
+// NaN values are drawn as blanks.
+func (s *Sparkline) Render(values []float64) string {
+	if s.width <= 0 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > s.width {
+		values = values[len(values)-s.width:]
+	}
+
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		minVal = math.Min(minVal, v)
+		maxVal = math.Max(maxVal, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			b.WriteRune(' ')
+			continue
+		}
+
+		level := 0
+		if maxVal > minVal {
+			level = int((v - minVal) / (maxVal - minVal) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+
+	return b.String()
+}
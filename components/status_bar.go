@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -21,10 +22,12 @@ func NewStatusBar(width int) *StatusBar {
 func (s *StatusBar) Render(
 	workload string,
 	mode string,
+	scrubStatus string,
 	lastUpdate time.Time,
-	snapshot *client.SnapshotData,
+	snapshot *client.QuantileSnapshotData,
 	scalerMetrics *client.ScalerMetrics,
 	forecasterHealthy, scalerHealthy bool,
+	breakers []client.BreakerStatus,
 	loading bool,
 	spinnerView string,
 	err error,
@@ -44,6 +47,11 @@ func (s *StatusBar) Render(
 	b.WriteString("  ")
 	b.WriteString(modeStyle.Render(fmt.Sprintf("[%s]", mode)))
 
+	if scrubStatus != "" {
+		b.WriteString("  ")
+		b.WriteString(modeStyle.Render(scrubStatus))
+	}
+
 	if loading {
 		b.WriteString("  ")
 		b.WriteString(spinnerView)
@@ -52,7 +60,7 @@ func (s *StatusBar) Render(
 
 	if !lastUpdate.IsZero() {
 		b.WriteString("  ")
-		b.WriteString(mutedStyle.Render(fmt.Sprintf("Last: %s ago", time.Since(lastUpdate).Round(time.Second))))
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Last: %s ago", clock.Since(lastUpdate).Round(time.Second))))
 	}
 
 	b.WriteString("\n")
@@ -71,6 +79,12 @@ func (s *StatusBar) Render(
 		statusLine += errorStyle.Render("Scaler ✗")
 	}
 
+	for _, breaker := range breakers {
+		if breaker.State != client.BreakerClosed {
+			statusLine += "  " + modeStyle.Render(FormatBreaker(breaker))
+		}
+	}
+
 	if snapshot != nil {
 		statusLine += fmt.Sprintf("  Forecast age: %s", snapshot.ForecastAge.Round(time.Second))
 		if snapshot.Stale {
@@ -91,3 +105,20 @@ func (s *StatusBar) Render(
 
 	return b.String()
 }
+
+// FormatBreaker describes a circuit breaker's state and, unless closed, when
+// the next probe runs.
+func FormatBreaker(status client.BreakerStatus) string {
+	switch status.State {
+	case client.BreakerOpen:
+		wait := clock.Until(status.NextProbe).Round(time.Second)
+		if wait <= 0 {
+			return fmt.Sprintf("%s breaker open, probe due", status.Endpoint)
+		}
+		return fmt.Sprintf("%s breaker open, probe in %s", status.Endpoint, wait)
+	case client.BreakerHalfOpen:
+		return fmt.Sprintf("%s breaker half-open, probing", status.Endpoint)
+	default:
+		return fmt.Sprintf("%s breaker closed", status.Endpoint)
+	}
+}
@@ -0,0 +1,92 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStatusBarGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	metrics := &client.ScalerMetrics{Active: true, DesiredReplicas: 3, ForecastAgeSeen: 12.5, ConnectionHealthy: true}
+
+	tests := []struct {
+		name                             string
+		mode, position                   string
+		metrics                          *client.ScalerMetrics
+		forecasterHealthy, scalerHealthy bool
+		breakers                         []client.BreakerStatus
+		snapshot                         *client.QuantileSnapshotData
+		loading                          bool
+		err                              error
+	}{
+		{
+			name:              "healthy",
+			mode:              "LIVE",
+			metrics:           metrics,
+			forecasterHealthy: true,
+			scalerHealthy:     true,
+		},
+		{
+			name:              "stale",
+			mode:              "LIVE",
+			metrics:           metrics,
+			forecasterHealthy: true,
+			scalerHealthy:     true,
+			snapshot:          client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), true, 5*time.Minute),
+		},
+		{
+			name:     "scrubbing",
+			mode:     "PAUSED",
+			position: "viewing snapshot 3 of 12 (generated 09:12:00)",
+			metrics:  metrics,
+			loading:  true,
+		},
+		{
+			name:              "outage",
+			mode:              "LIVE",
+			forecasterHealthy: true,
+			breakers: []client.BreakerStatus{
+				{Endpoint: "scaler", State: client.BreakerOpen, Failures: 5, NextProbe: uitest.Now.Add(20 * time.Second)},
+			},
+			err: errors.New("failed to fetch metrics: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		for _, width := range []int{60, 100, 140} {
+			name := fmt.Sprintf("status_bar_%s_%d", tt.name, width)
+			t.Run(name, func(t *testing.T) {
+				bar := NewStatusBar(width)
+				got := bar.Render("checkout", tt.mode, tt.position, uitest.Now.Add(-2*time.Second), tt.snapshot, tt.metrics,
+					tt.forecasterHealthy, tt.scalerHealthy, tt.breakers, tt.loading, "⣾", tt.err)
+				uitest.Golden(t, name, got)
+			})
+		}
+	}
+}
+
+func TestStatusBarStale(t *testing.T) {
+	uitest.PinClock(t)
+
+	for _, stale := range []bool{false, true} {
+		snapshot := client.NewQuantileSnapshotData(uitest.Snapshot("checkout"), stale, 5*time.Minute)
+		for _, width := range []int{60, 140} {
+			got := NewStatusBar(width).Render("checkout", "LIVE", "", uitest.Now, snapshot, nil, true, true, nil, false, "", nil)
+			lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+			if marked := strings.Contains(lines[1], "[STALE]"); marked != stale {
+				t.Errorf("width %d: status line %q marked stale = %v, want %v", width, lines[1], marked, stale)
+			}
+			if rule := lines[len(lines)-1]; lipgloss.Width(rule) != width {
+				t.Errorf("width %d: rule is %d columns wide", width, lipgloss.Width(rule))
+			}
+		}
+	}
+}
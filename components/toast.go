@@ -3,6 +3,7 @@ package components
 import (
 	"time"
 
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -37,13 +38,13 @@ func (t *ToastManager) Add(message string, toastType ToastType, duration time.Du
 	toast := Toast{
 		Message:   message,
 		Type:      toastType,
-		ExpiresAt: time.Now().Add(duration),
+		ExpiresAt: clock.Now().Add(duration),
 	}
 	t.toasts = append(t.toasts, toast)
 }
 
 func (t *ToastManager) Update() {
-	now := time.Now()
+	now := clock.Now()
 	var active []Toast
 	for _, toast := range t.toasts {
 		if toast.ExpiresAt.After(now) {
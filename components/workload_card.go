@@ -0,0 +1,92 @@
+package components
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// WorkloadCard renders a compact summary of one workload for the overview
+// grid.
+type WorkloadCard struct {
+	width int
+}
+
+// NewWorkloadCard creates a card with the given outer width.
+func NewWorkloadCard(width int) *WorkloadCard {
+	return &WorkloadCard{width: width}
+}
+
+// Render renders the card. snapshot may be nil while loading or after err.
+func (w *WorkloadCard) Render(
+	info client.WorkloadInfo,
+	snapshot *client.QuantileSnapshotData,
+	err error,
+	leadTime time.Duration,
+	selected bool,
+) string {
+	innerWidth := max(w.width-4, 10)
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	sparkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+
+	name := info.Name
+	if len(name) > innerWidth {
+		name = name[:innerWidth-3] + "..."
+	}
+
+	lines := []string{titleStyle.Render(name)}
+
+	switch {
+	case err != nil:
+		msg := err.Error()
+		if len(msg) > innerWidth*2 {
+			msg = msg[:innerWidth*2-3] + "..."
+		}
+		lines = append(lines, errorStyle.Width(innerWidth).Render("✗ "+msg))
+
+	case snapshot == nil:
+		lines = append(lines, mutedStyle.Render("Loading..."))
+
+	default:
+		values := snapshot.Snapshot.Quantiles["p50"]
+		if len(values) == 0 {
+			values = snapshot.Snapshot.Values
+		}
+		lines = append(lines, sparkStyle.Render(NewSparkline(innerWidth).Render(values)))
+
+		current := "-"
+		if info.CurrentReplicas > 0 {
+			current = fmt.Sprintf("%d", info.CurrentReplicas)
+		}
+		desired := "-"
+		if i := snapshot.LeadTimeIndex; i >= 0 && i < len(snapshot.Snapshot.DesiredReplicas) {
+			desired = fmt.Sprintf("%d", snapshot.Snapshot.DesiredReplicas[i])
+		}
+		lines = append(lines, fmt.Sprintf("Replicas %s → %s @%s", current, desired, formatTimeOffset(leadTime)))
+
+		age := fmt.Sprintf("Age %s", snapshot.ForecastAge.Round(time.Second))
+		if snapshot.Stale {
+			age += "  " + warningStyle.Render("⚠ stale")
+		}
+		lines = append(lines, age)
+	}
+
+	border := lipgloss.Color("241")
+	if selected {
+		border = lipgloss.Color("39")
+	}
+
+	return lipgloss.NewStyle().
+		Width(w.width-2).
+		Height(4).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,38 @@
+package components
+
+// chartWindow is the range of steps a chart plots.
+type chartWindow struct {
+	start, count int
+}
+
+// slice returns the part of values inside the window.
+func (w chartWindow) slice(values []float64) []float64 {
+	if w.start >= len(values) {
+		return nil
+	}
+	return values[w.start:min(w.start+w.count, len(values))]
+}
+
+// contains reports whether step i is inside the window.
+func (w chartWindow) contains(i int) bool {
+	return i >= w.start && i < w.start+w.count
+}
+
+// zoom holds the steps a chart plots when zoomed in.
+type zoom struct {
+	windowStart, windowSteps int
+}
+
+// SetWindow zooms the chart to steps steps starting at step start. A steps
+// of 0 shows the whole horizon.
+func (z *zoom) SetWindow(start, steps int) {
+	z.windowStart, z.windowSteps = start, steps
+}
+
+// window returns the plotted steps of a forecast with n steps.
+func (z *zoom) window(n int) chartWindow {
+	if z.windowSteps <= 0 || z.windowSteps >= n {
+		return chartWindow{start: 0, count: n}
+	}
+	return chartWindow{start: min(max(z.windowStart, 0), n-z.windowSteps), count: z.windowSteps}
+}
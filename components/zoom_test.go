@@ -0,0 +1,48 @@
+package components
+
+import "testing"
+
+func TestZoomWindow(t *testing.T) {
+	tests := []struct {
+		name         string
+		start, steps int
+		n            int
+		want         chartWindow
+	}{
+		{name: "whole horizon", n: 30, want: chartWindow{0, 30}},
+		{name: "window", start: 4, steps: 8, n: 30, want: chartWindow{4, 8}},
+		{name: "before the start", start: -3, steps: 8, n: 30, want: chartWindow{0, 8}},
+		{name: "past the end", start: 25, steps: 8, n: 30, want: chartWindow{22, 8}},
+		{name: "wider than the horizon", start: 4, steps: 40, n: 30, want: chartWindow{0, 30}},
+		{name: "as wide as the horizon", start: 4, steps: 30, n: 30, want: chartWindow{0, 30}},
+		{name: "empty", start: 4, steps: 8, want: chartWindow{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var z zoom
+			z.SetWindow(tt.start, tt.steps)
+			if got := z.window(tt.n); got != tt.want {
+				t.Errorf("window(%d) = %+v, want %+v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChartWindow(t *testing.T) {
+	w := chartWindow{start: 2, count: 3}
+	if got := w.slice([]float64{0, 1, 2, 3, 4, 5}); len(got) != 3 || got[0] != 2 || got[2] != 4 {
+		t.Errorf("slice = %v, want [2 3 4]", got)
+	}
+	if got := w.slice([]float64{0, 1, 2, 3}); len(got) != 2 {
+		t.Errorf("slice of a shorter series = %v, want [2 3]", got)
+	}
+	if got := w.slice([]float64{0, 1}); got != nil {
+		t.Errorf("slice past the series = %v, want nil", got)
+	}
+	for i, want := range []bool{false, false, true, true, true, false} {
+		if got := w.contains(i); got != want {
+			t.Errorf("contains(%d) = %v, want %v", i, got, want)
+		}
+	}
+}
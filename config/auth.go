@@ -0,0 +1,54 @@
+package config
+
+import "flag"
+
+// AuthConfig holds the credentials for one endpoint.
+type AuthConfig struct {
+	BearerToken     string `json:"bearer_token,omitempty"`
+	BearerTokenFile string `json:"bearer_token_file,omitempty"`
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty"`
+	CertFile        string `json:"cert_file,omitempty"`
+	KeyFile         string `json:"key_file,omitempty"`
+	CAFile          string `json:"ca_file,omitempty"`
+}
+
+// authFlag describes one credential setting and where it is read from.
+type authFlag struct {
+	suffix    string
+	envSuffix string
+	usage     string
+	field     func(*AuthConfig) *string
+}
+
+var authFlags = []authFlag{
+	{"token", "TOKEN", "bearer token", func(a *AuthConfig) *string { return &a.BearerToken }},
+	{"token-file", "TOKEN_FILE", "file containing a bearer token, re-read when it changes", func(a *AuthConfig) *string { return &a.BearerTokenFile }},
+	{"username", "USERNAME", "HTTP basic auth username", func(a *AuthConfig) *string { return &a.Username }},
+	{"password", "PASSWORD", "HTTP basic auth password", func(a *AuthConfig) *string { return &a.Password }},
+	{"cert", "CERT_FILE", "client certificate file for mTLS", func(a *AuthConfig) *string { return &a.CertFile }},
+	{"key", "KEY_FILE", "client key file for mTLS", func(a *AuthConfig) *string { return &a.KeyFile }},
+	{"ca", "CA_FILE", "CA bundle file used to verify the server", func(a *AuthConfig) *string { return &a.CAFile }},
+}
+
+// registerAuthFlags registers the credential flags for one endpoint, such as
+// --forecaster-token, defaulting to the file value and then FORECASTER_TOKEN.
+func registerAuthFlags(fs *flag.FlagSet, endpoint, envPrefix, label string, dst *AuthConfig, file AuthConfig) {
+	for _, f := range authFlags {
+		def := *f.field(&file)
+		if def == "" {
+			def = getEnv(envPrefix+"_"+f.envSuffix, "")
+		}
+		fs.StringVar(f.field(dst), endpoint+"-"+f.suffix, def, label+" "+f.usage)
+	}
+}
+
+// mergeAuth copies the non-empty credentials of src onto dst, except for
+// those whose flags are in explicit.
+func mergeAuth(dst *AuthConfig, src AuthConfig, endpoint string, explicit map[string]bool) {
+	for _, f := range authFlags {
+		if value := *f.field(&src); value != "" && !explicit[endpoint+"-"+f.suffix] {
+			*f.field(dst) = value
+		}
+	}
+}
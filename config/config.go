@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
+
+	"github.com/HatiCode/kedastral-tui/history"
 )
 
 // Config holds all TUI configuration.
@@ -19,10 +22,52 @@ type Config struct {
 	LeadTime        time.Duration `json:"lead_time,omitempty"`
 	LogLevel        string        `json:"log_level,omitempty"`
 	Theme           string        `json:"theme,omitempty"`
+	HistorySize     int           `json:"history_size,omitempty"`
+	PrometheusURL   string        `json:"prometheus_url,omitempty"`
+	ObservedQuery   string        `json:"observed_query,omitempty"`
+
+	Retries           *int          `json:"retries,omitempty"` // Nil means the default; 0 disables retrying
+	RetryBackoff      time.Duration `json:"retry_backoff,omitempty"`
+	BreakerThreshold  *int          `json:"breaker_threshold,omitempty"` // Nil means the default; 0 disables the breakers
+	BreakerCooldown   time.Duration `json:"breaker_cooldown,omitempty"`
+	Stream            bool          `json:"stream,omitempty"`
+	ChartYLabels      int           `json:"chart_y_labels,omitempty"`
+	HighlightQuantile string        `json:"highlight_quantile,omitempty"`
+
+	// Session recording, replay, static files and demo mode apply to a
+	// single run
+	Demo         bool    `json:"-"`
+	ForecastFile string  `json:"-"`
+	Record       string  `json:"-"`
+	Replay       string  `json:"-"`
+	ReplaySpeed  float64 `json:"-"`
+
+	ForecasterAuth AuthConfig `json:"forecaster_auth,omitzero"`
+	ScalerAuth     AuthConfig `json:"scaler_auth,omitzero"`
+	PrometheusAuth AuthConfig `json:"prometheus_auth,omitzero"`
+
+	Contexts       map[string]Context `json:"contexts,omitempty"`
+	CurrentContext string             `json:"current_context,omitempty"`
+
+	base     *Context        // Connection settings before any context was applied
+	explicit map[string]bool // Flags set on the command line
 }
 
 // ParseFlags parses configuration from file, environment variables, and command-line flags.
 func ParseFlags() (*Config, bool) {
+	cfg, needsSetup, err := Parse(flag.CommandLine, os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg, needsSetup
+}
+
+// Parse registers the configuration flags on fs and parses args, using the
+// config file and environment variables as defaults. Callers may register
+// additional flags on fs before calling Parse. The returned bool reports
+// whether required settings are missing and setup is needed.
+func Parse(fs *flag.FlagSet, args []string) (*Config, bool, error) {
 	fileConfig := loadConfigFile()
 
 	cfg := &Config{}
@@ -62,24 +107,143 @@ func ParseFlags() (*Config, bool) {
 		themeDefault = getEnv("THEME", "dark")
 	}
 
-	flag.StringVar(&cfg.ForecasterURL, "forecaster-url", forecasterDefault, "Forecaster HTTP URL (required)")
-	flag.StringVar(&cfg.ScalerURL, "scaler-url", scalerDefault, "Scaler HTTP URL")
-	flag.StringVar(&cfg.Workload, "workload", workloadDefault, "Workload name to monitor (required)")
-	flag.DurationVar(&cfg.RefreshInterval, "refresh-interval", refreshDefault, "Refresh interval in live mode")
-	flag.DurationVar(&cfg.LeadTime, "lead-time", leadTimeDefault, "Lead time for replica selection highlighting")
-	flag.StringVar(&cfg.LogLevel, "log-level", logLevelDefault, "Log level: debug, info, warn, error")
-	flag.StringVar(&cfg.Theme, "theme", themeDefault, "Color theme: dark, light")
+	historyDefault := fileConfig.HistorySize
+	if historyDefault == 0 {
+		historyDefault = getEnvInt("HISTORY_SIZE", history.DefaultSize)
+	}
+
+	prometheusDefault := fileConfig.PrometheusURL
+	if prometheusDefault == "" {
+		prometheusDefault = getEnv("PROMETHEUS_URL", "")
+	}
+
+	observedQueryDefault := fileConfig.ObservedQuery
+	if observedQueryDefault == "" {
+		observedQueryDefault = getEnv("OBSERVED_QUERY", "")
+	}
+
+	retriesDefault := getEnvInt("RETRIES", 2)
+	if fileConfig.Retries != nil {
+		retriesDefault = *fileConfig.Retries
+	}
 
-	flag.Parse()
+	retryBackoffDefault := fileConfig.RetryBackoff
+	if retryBackoffDefault == 0 {
+		retryBackoffDefault = getEnvDuration("RETRY_BACKOFF", 200*time.Millisecond)
+	}
+
+	breakerThresholdDefault := getEnvInt("BREAKER_THRESHOLD", 5)
+	if fileConfig.BreakerThreshold != nil {
+		breakerThresholdDefault = *fileConfig.BreakerThreshold
+	}
+
+	breakerCooldownDefault := fileConfig.BreakerCooldown
+	if breakerCooldownDefault == 0 {
+		breakerCooldownDefault = getEnvDuration("BREAKER_COOLDOWN", 30*time.Second)
+	}
+
+	streamDefault := fileConfig.Stream || getEnvBool("STREAM", false)
+
+	chartYLabelsDefault := fileConfig.ChartYLabels
+	if chartYLabelsDefault == 0 {
+		chartYLabelsDefault = getEnvInt("CHART_Y_LABELS", 3)
+	}
+
+	highlightDefault := fileConfig.HighlightQuantile
+	if highlightDefault == "" {
+		highlightDefault = getEnv("HIGHLIGHT_QUANTILE", "p50")
+	}
+
+	contextDefault := getEnv("KEDASTRAL_CONTEXT", fileConfig.CurrentContext)
+
+	fs.StringVar(&cfg.CurrentContext, "context", contextDefault, "Named context from the config file to connect with")
+	fs.StringVar(&cfg.ForecasterURL, "forecaster-url", forecasterDefault, "Forecaster HTTP URL (required)")
+	fs.StringVar(&cfg.ScalerURL, "scaler-url", scalerDefault, "Scaler HTTP URL")
+	fs.StringVar(&cfg.Workload, "workload", workloadDefault, "Workload name to monitor (required)")
+	fs.DurationVar(&cfg.RefreshInterval, "refresh-interval", refreshDefault, "Refresh interval in live mode")
+	fs.DurationVar(&cfg.LeadTime, "lead-time", leadTimeDefault, "Lead time for replica selection highlighting")
+	fs.StringVar(&cfg.LogLevel, "log-level", logLevelDefault, "Log level: debug, info, warn, error")
+	fs.StringVar(&cfg.Theme, "theme", themeDefault, "Color theme: dark, light")
+	fs.StringVar(&cfg.PrometheusURL, "prometheus-url", prometheusDefault, "Prometheus-compatible URL for observed metric values (default: forecaster)")
+	fs.StringVar(&cfg.ObservedQuery, "observed-query", observedQueryDefault, "PromQL template for observed values; $metric and $workload are substituted")
+	fs.IntVar(&cfg.HistorySize, "history-size", historyDefault, "Number of forecast snapshots kept per workload for scrubbing")
+	cfg.Retries = fs.Int("retries", retriesDefault, "Retries for failed requests, with jittered exponential backoff (0 disables)")
+	fs.DurationVar(&cfg.RetryBackoff, "retry-backoff", retryBackoffDefault, "Delay before the first retry; doubled for each further retry")
+	cfg.BreakerThreshold = fs.Int("breaker-threshold", breakerThresholdDefault, "Consecutive failures before an endpoint's circuit breaker opens (0 disables)")
+	fs.DurationVar(&cfg.BreakerCooldown, "breaker-cooldown", breakerCooldownDefault, "Time an open circuit breaker waits before probing the endpoint again")
+	fs.BoolVar(&cfg.Stream, "stream", streamDefault, "Receive forecasts over the forecaster's event stream, falling back to polling if unsupported")
+	fs.IntVar(&cfg.ChartYLabels, "chart-y-labels", chartYLabelsDefault, "Number of values labelled on chart y-axes")
+	fs.StringVar(&cfg.HighlightQuantile, "highlight-quantile", highlightDefault, "Quantile drawn as the highlighted chart line, such as p50 or p90")
+	fs.BoolVar(&cfg.Demo, "demo", false, "Run against built-in synthetic workloads instead of a Kedastral deployment")
+	fs.StringVar(&cfg.ForecastFile, "forecast-file", "", "Show forecasts from a static JSON file instead of the forecaster")
+	fs.StringVar(&cfg.Record, "record", "", "Record every forecast, scaler metrics and health result to a JSONL file")
+	fs.StringVar(&cfg.Replay, "replay", "", "Replay a session recorded with --record instead of connecting")
+	fs.Float64Var(&cfg.ReplaySpeed, "replay-speed", 1, "Playback speed multiplier for --replay")
+
+	registerAuthFlags(fs, "forecaster", "FORECASTER", "Forecaster", &cfg.ForecasterAuth, fileConfig.ForecasterAuth)
+	registerAuthFlags(fs, "scaler", "SCALER", "Scaler", &cfg.ScalerAuth, fileConfig.ScalerAuth)
+	registerAuthFlags(fs, "prometheus", "PROMETHEUS", "Prometheus", &cfg.PrometheusAuth, fileConfig.PrometheusAuth)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+
+	// Context settings override the file and environment, but not flags
+	cfg.Contexts = fileConfig.Contexts
+	cfg.explicit = make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		cfg.explicit[f.Name] = true
+	})
+	base := cfg.connection()
+	cfg.base = &base
+
+	if cfg.CurrentContext != "" {
+		if err := cfg.applyContext(cfg.CurrentContext, cfg.explicit); err != nil {
+			return nil, false, err
+		}
+	}
 
-	needsSetup := cfg.ForecasterURL == "" || cfg.Workload == ""
+	needsSetup := (cfg.ForecasterURL == "" || cfg.Workload == "") && !cfg.Offline()
 
 	if cfg.RefreshInterval > 0 && cfg.RefreshInterval < 1*time.Second {
-		fmt.Fprintln(os.Stderr, "Error: --refresh-interval must be at least 1 second")
-		os.Exit(1)
+		return nil, false, fmt.Errorf("--refresh-interval must be at least 1 second")
 	}
 
-	return cfg, needsSetup
+	if cfg.HistorySize < 1 {
+		return nil, false, fmt.Errorf("--history-size must be at least 1")
+	}
+
+	if cfg.Record != "" && cfg.Replay != "" {
+		return nil, false, fmt.Errorf("--record and --replay cannot be used together")
+	}
+
+	if cfg.Demo && cfg.ForecastFile != "" {
+		return nil, false, fmt.Errorf("--demo and --forecast-file cannot be used together")
+	}
+
+	if cfg.ReplaySpeed <= 0 {
+		return nil, false, fmt.Errorf("--replay-speed must be positive")
+	}
+
+	if *cfg.Retries < 0 {
+		return nil, false, fmt.Errorf("--retries must not be negative")
+	}
+
+	if *cfg.BreakerThreshold < 0 {
+		return nil, false, fmt.Errorf("--breaker-threshold must not be negative")
+	}
+
+	if cfg.ChartYLabels < 2 {
+		return nil, false, fmt.Errorf("--chart-y-labels must be at least 2")
+	}
+
+	return cfg, needsSetup, nil
+}
+
+// Offline reports whether the data comes from somewhere other than the
+// forecaster and scaler: a demo, a forecast file or a replayed session.
+func (c *Config) Offline() bool {
+	return c.Demo || c.ForecastFile != "" || c.Replay != ""
 }
 
 func getEnv(key, defaultValue string) string {
@@ -98,6 +262,24 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
 // getConfigPath returns the path to the configuration file.
 func getConfigPath() string {
 	homeDir, err := os.UserHomeDir()
@@ -113,7 +295,29 @@ func SaveConfig(cfg *Config) error {
 		return fmt.Errorf("unable to determine config path")
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	out := *cfg
+	existing, err := LoadConfigFile()
+	if err != nil {
+		existing = &Config{}
+	}
+
+	// Credentials may come from flags or the environment; only ever persist
+	// what the file already contained
+	out.ForecasterAuth = existing.ForecasterAuth
+	out.ScalerAuth = existing.ScalerAuth
+	out.PrometheusAuth = existing.PrometheusAuth
+
+	if (cfg.CurrentContext != "" && err == nil) || cfg.Offline() {
+		// Connection settings belong to the context, and offline runs have
+		// none of their own; keep the file's own values
+		out.ForecasterURL = existing.ForecasterURL
+		out.ScalerURL = existing.ScalerURL
+		out.Workload = existing.Workload
+		out.PrometheusURL = existing.PrometheusURL
+		out.ObservedQuery = existing.ObservedQuery
+	}
+
+	data, err := json.MarshalIndent(&out, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
@@ -123,7 +327,8 @@ func SaveConfig(cfg *Config) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// The file may hold credentials, so keep it private to the user
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
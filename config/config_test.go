@@ -0,0 +1,56 @@
+package config
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestParseZeroDisables(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		args    []string
+		retries int
+		breaker int
+	}{
+		{"defaults", `{}`, nil, 2, 5},
+		{"file zero", `{"retries": 0, "breaker_threshold": 0}`, nil, 0, 0},
+		{"file values", `{"retries": 4, "breaker_threshold": 3}`, nil, 4, 3},
+		{"flag zero over file", `{"retries": 4, "breaker_threshold": 3}`, []string{"--retries=0", "--breaker-threshold=0"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfigFile(t, tt.file)
+			t.Setenv("RETRIES", "")
+			t.Setenv("BREAKER_THRESHOLD", "")
+
+			cfg, _, err := Parse(flag.NewFlagSet("test", flag.ContinueOnError), tt.args)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if *cfg.Retries != tt.retries || *cfg.BreakerThreshold != tt.breaker {
+				t.Fatalf("retries, breaker threshold = %d, %d; want %d, %d", *cfg.Retries, *cfg.BreakerThreshold, tt.retries, tt.breaker)
+			}
+
+			// Saving must keep an explicit zero rather than drop it
+			if err := SaveConfig(cfg); err != nil {
+				t.Fatalf("SaveConfig() error = %v", err)
+			}
+			saved, err := LoadConfigFile()
+			if err != nil {
+				t.Fatalf("LoadConfigFile() error = %v", err)
+			}
+			if saved.Retries == nil || *saved.Retries != tt.retries || saved.BreakerThreshold == nil || *saved.BreakerThreshold != tt.breaker {
+				t.Fatalf("saved retries, breaker threshold = %v, %v; want %d, %d", saved.Retries, saved.BreakerThreshold, tt.retries, tt.breaker)
+			}
+		})
+	}
+}
+
+func TestParseRejectsNegativeRetries(t *testing.T) {
+	writeConfigFile(t, `{"retries": -1}`)
+	if _, _, err := Parse(flag.NewFlagSet("test", flag.ContinueOnError), nil); err == nil {
+		t.Fatalf("Parse() accepted negative retries")
+	}
+}
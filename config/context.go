@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Context is a named set of connection settings, selected with --context.
+// Empty fields fall back to the top-level configuration.
+type Context struct {
+	ForecasterURL string `json:"forecaster_url,omitempty"`
+	ScalerURL     string `json:"scaler_url,omitempty"`
+	Workload      string `json:"workload,omitempty"`
+	PrometheusURL string `json:"prometheus_url,omitempty"`
+	ObservedQuery string `json:"observed_query,omitempty"`
+
+	ForecasterAuth AuthConfig `json:"forecaster_auth,omitzero"`
+	ScalerAuth     AuthConfig `json:"scaler_auth,omitzero"`
+	PrometheusAuth AuthConfig `json:"prometheus_auth,omitzero"`
+}
+
+// ContextNames returns the configured context names in sorted order.
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseContext switches the connection settings to the named context.
+// Settings from the previous context are dropped first, while settings
+// given as flags keep precedence.
+func (c *Config) UseContext(name string) error {
+	if _, ok := c.Contexts[name]; !ok {
+		return fmt.Errorf("unknown context %q", name)
+	}
+
+	if c.base != nil {
+		c.setConnection(*c.base)
+	}
+	if err := c.applyContext(name, c.explicit); err != nil {
+		return err
+	}
+	c.CurrentContext = name
+	return nil
+}
+
+// connection returns the settings of c that a context can override.
+func (c *Config) connection() Context {
+	return Context{
+		ForecasterURL:  c.ForecasterURL,
+		ScalerURL:      c.ScalerURL,
+		Workload:       c.Workload,
+		PrometheusURL:  c.PrometheusURL,
+		ObservedQuery:  c.ObservedQuery,
+		ForecasterAuth: c.ForecasterAuth,
+		ScalerAuth:     c.ScalerAuth,
+		PrometheusAuth: c.PrometheusAuth,
+	}
+}
+
+func (c *Config) setConnection(ctx Context) {
+	c.ForecasterURL = ctx.ForecasterURL
+	c.ScalerURL = ctx.ScalerURL
+	c.Workload = ctx.Workload
+	c.PrometheusURL = ctx.PrometheusURL
+	c.ObservedQuery = ctx.ObservedQuery
+	c.ForecasterAuth = ctx.ForecasterAuth
+	c.ScalerAuth = ctx.ScalerAuth
+	c.PrometheusAuth = ctx.PrometheusAuth
+}
+
+// applyContext copies the context's settings onto c, except for the
+// settings whose flag names are in explicit.
+func (c *Config) applyContext(name string, explicit map[string]bool) error {
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return fmt.Errorf("unknown context %q", name)
+	}
+
+	apply := func(dst *string, value, flagName string) {
+		if value != "" && !explicit[flagName] {
+			*dst = value
+		}
+	}
+
+	apply(&c.ForecasterURL, ctx.ForecasterURL, "forecaster-url")
+	apply(&c.ScalerURL, ctx.ScalerURL, "scaler-url")
+	apply(&c.Workload, ctx.Workload, "workload")
+	apply(&c.PrometheusURL, ctx.PrometheusURL, "prometheus-url")
+	apply(&c.ObservedQuery, ctx.ObservedQuery, "observed-query")
+
+	mergeAuth(&c.ForecasterAuth, ctx.ForecasterAuth, "forecaster", explicit)
+	mergeAuth(&c.ScalerAuth, ctx.ScalerAuth, "scaler", explicit)
+	mergeAuth(&c.PrometheusAuth, ctx.PrometheusAuth, "prometheus", explicit)
+
+	return nil
+}
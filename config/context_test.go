@@ -0,0 +1,93 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfigFile writes the config file read by Parse into a fresh home
+// directory.
+func writeConfigFile(t *testing.T, content string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, key := range []string{"FORECASTER_URL", "SCALER_URL", "WORKLOAD", "PROMETHEUS_URL", "OBSERVED_QUERY", "KEDASTRAL_CONTEXT"} {
+		t.Setenv(key, "")
+	}
+
+	path := filepath.Join(home, ".config", "kedastral-tui", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUseContextDropsPreviousContext(t *testing.T) {
+	writeConfigFile(t, `{
+		"forecaster_url": "http://base:8081",
+		"contexts": {
+			"a": {"forecaster_url": "http://a:8081", "scaler_url": "http://a:8082", "prometheus_url": "http://a:9090"},
+			"b": {"forecaster_url": "http://b:8081"}
+		}
+	}`)
+
+	cfg, _, err := Parse(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--context=a", "--workload=checkout"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.PrometheusURL != "http://a:9090" {
+		t.Fatalf("PrometheusURL = %q with context a, want http://a:9090", cfg.PrometheusURL)
+	}
+
+	if err := cfg.UseContext("b"); err != nil {
+		t.Fatalf("UseContext(b) error = %v", err)
+	}
+
+	tests := []struct {
+		name, got, want string
+	}{
+		{"ForecasterURL", cfg.ForecasterURL, "http://b:8081"},
+		{"ScalerURL", cfg.ScalerURL, "http://localhost:8082"},
+		{"PrometheusURL", cfg.PrometheusURL, ""},
+		{"Workload", cfg.Workload, "checkout"},
+		{"CurrentContext", cfg.CurrentContext, "b"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q after switching to b, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if err := cfg.UseContext("missing"); err == nil {
+		t.Errorf("UseContext(missing) succeeded")
+	}
+}
+
+func TestUseContextKeepsFlags(t *testing.T) {
+	writeConfigFile(t, `{
+		"contexts": {
+			"a": {"forecaster_url": "http://a:8081", "workload": "search"},
+			"b": {"forecaster_url": "http://b:8081", "workload": "payments"}
+		}
+	}`)
+
+	cfg, _, err := Parse(flag.NewFlagSet("test", flag.ContinueOnError), []string{"--context=a", "--forecaster-url=http://flag:8081"})
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := cfg.UseContext("b"); err != nil {
+		t.Fatalf("UseContext(b) error = %v", err)
+	}
+
+	if cfg.ForecasterURL != "http://flag:8081" {
+		t.Errorf("ForecasterURL = %q, want the flag's value", cfg.ForecasterURL)
+	}
+	if cfg.Workload != "payments" {
+		t.Errorf("Workload = %q, want context b's payments", cfg.Workload)
+	}
+}
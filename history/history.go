@@ -0,0 +1,120 @@
+// Package history keeps a bounded in-memory record of forecast snapshots.
+package history
+
+import "github.com/HatiCode/kedastral-tui/client"
+
+// DefaultSize is the number of snapshots kept per workload when none is configured.
+const DefaultSize = 720
+
+// Ring is a fixed-capacity buffer of snapshots ordered oldest first.
+type Ring struct {
+	items []*client.QuantileSnapshotData
+	start int
+	size  int
+}
+
+// NewRing creates a ring holding at most capacity snapshots.
+func NewRing(capacity int) *Ring {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Ring{items: make([]*client.QuantileSnapshotData, capacity)}
+}
+
+// Push appends a snapshot, evicting the oldest one when the ring is full.
+// It reports whether an eviction happened.
+func (r *Ring) Push(snapshot *client.QuantileSnapshotData) bool {
+	if r.size < len(r.items) {
+		r.items[(r.start+r.size)%len(r.items)] = snapshot
+		r.size++
+		return false
+	}
+
+	r.items[r.start] = snapshot
+	r.start = (r.start + 1) % len(r.items)
+	return true
+}
+
+// Len returns the number of snapshots in the ring.
+func (r *Ring) Len() int {
+	return r.size
+}
+
+// Cap returns the maximum number of snapshots the ring can hold.
+func (r *Ring) Cap() int {
+	return len(r.items)
+}
+
+// At returns the snapshot at index i, where 0 is the oldest.
+func (r *Ring) At(i int) *client.QuantileSnapshotData {
+	if i < 0 || i >= r.size {
+		return nil
+	}
+	return r.items[(r.start+i)%len(r.items)]
+}
+
+// Latest returns the most recent snapshot, or nil if the ring is empty.
+func (r *Ring) Latest() *client.QuantileSnapshotData {
+	return r.At(r.size - 1)
+}
+
+// Items returns the snapshots oldest first.
+func (r *Ring) Items() []*client.QuantileSnapshotData {
+	items := make([]*client.QuantileSnapshotData, r.size)
+	for i := range items {
+		items[i] = r.At(i)
+	}
+	return items
+}
+
+// Store keeps a Ring of snapshots for each workload.
+type Store struct {
+	capacity int
+	rings    map[string]*Ring
+}
+
+// NewStore creates a store that keeps at most capacity snapshots per workload.
+func NewStore(capacity int) *Store {
+	if capacity < 1 {
+		capacity = DefaultSize
+	}
+	return &Store{
+		capacity: capacity,
+		rings:    make(map[string]*Ring),
+	}
+}
+
+// Add records a snapshot for the workload. A snapshot generated at the same
+// time as the latest recorded one is the same forecast fetched again and is
+// skipped. It reports whether the snapshot was added and whether an older
+// snapshot was evicted to make room.
+func (s *Store) Add(workload string, snapshot *client.QuantileSnapshotData) (added, evicted bool) {
+	if snapshot == nil {
+		return false, false
+	}
+
+	ring, ok := s.rings[workload]
+	if !ok {
+		ring = NewRing(s.capacity)
+		s.rings[workload] = ring
+	}
+
+	if latest := ring.Latest(); latest != nil && latest.Snapshot.GeneratedAt.Equal(snapshot.Snapshot.GeneratedAt) {
+		return false, false
+	}
+
+	return true, ring.Push(snapshot)
+}
+
+// Get returns the ring for the workload, or nil if nothing was recorded.
+func (s *Store) Get(workload string) *Ring {
+	return s.rings[workload]
+}
+
+// Len returns the number of snapshots recorded for the workload.
+func (s *Store) Len(workload string) int {
+	if ring, ok := s.rings[workload]; ok {
+		return ring.Len()
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+)
+
+var start = time.Date(2026, time.March, 14, 9, 0, 0, 0, time.UTC)
+
+// snapshot returns a snapshot generated i minutes after start.
+func snapshot(i int) *client.QuantileSnapshotData {
+	return &client.QuantileSnapshotData{
+		Snapshot: client.QuantileSnapshot{GeneratedAt: start.Add(time.Duration(i) * time.Minute)},
+	}
+}
+
+// minutes returns the minute offsets of the ring's snapshots, oldest first.
+func minutes(r *Ring) []int {
+	var got []int
+	for _, s := range r.Items() {
+		got = append(got, int(s.Snapshot.GeneratedAt.Sub(start).Minutes()))
+	}
+	return got
+}
+
+func TestRing(t *testing.T) {
+	r := NewRing(3)
+	if r.Latest() != nil || r.At(0) != nil {
+		t.Fatalf("empty ring returned a snapshot")
+	}
+
+	for i := range 3 {
+		if r.Push(snapshot(i)) {
+			t.Fatalf("Push(%d) evicted before the ring was full", i)
+		}
+	}
+	if !r.Push(snapshot(3)) {
+		t.Fatalf("Push(3) into a full ring did not evict")
+	}
+	r.Push(snapshot(4))
+
+	if got := minutes(r); len(got) != 3 || got[0] != 2 || got[1] != 3 || got[2] != 4 {
+		t.Errorf("items = %v, want [2 3 4]", got)
+	}
+	if r.Len() != 3 || r.Cap() != 3 {
+		t.Errorf("len, cap = %d, %d; want 3, 3", r.Len(), r.Cap())
+	}
+	if got := r.Latest().Snapshot.GeneratedAt; !got.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("latest generated at %v, want minute 4", got)
+	}
+	if r.At(-1) != nil || r.At(3) != nil {
+		t.Errorf("At out of range returned a snapshot")
+	}
+
+	if NewRing(0).Cap() != 1 {
+		t.Errorf("NewRing(0) capacity = %d, want 1", NewRing(0).Cap())
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := NewStore(2)
+
+	if added, _ := s.Add("checkout", snapshot(0)); !added {
+		t.Fatalf("first snapshot not added")
+	}
+	if added, _ := s.Add("checkout", snapshot(0)); added {
+		t.Errorf("refetched snapshot added again")
+	}
+	if added, _ := s.Add("checkout", nil); added {
+		t.Errorf("nil snapshot added")
+	}
+	s.Add("checkout", snapshot(1))
+	if _, evicted := s.Add("checkout", snapshot(2)); !evicted {
+		t.Errorf("third snapshot into a store of 2 did not evict")
+	}
+	s.Add("search", snapshot(5))
+
+	if got := minutes(s.Get("checkout")); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("checkout = %v, want [1 2]", got)
+	}
+	if s.Len("search") != 1 || s.Len("payments") != 0 || s.Get("payments") != nil {
+		t.Errorf("workloads share or invent history")
+	}
+
+	if got := NewStore(0).capacity; got != DefaultSize {
+		t.Errorf("NewStore(0) capacity = %d, want %d", got, DefaultSize)
+	}
+}
@@ -0,0 +1,225 @@
+// Package uitest drives Bubble Tea models from tests with scripted input, a
+// pinned clock and a stub data source, and compares what they render against
+// golden files.
+package uitest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func init() {
+	// Golden files hold plain text, whatever terminal runs the tests
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// cmdTimeout bounds how long the driver waits for a command. Fetches from a
+// stub source and timers on a pinned clock finish well within it; a command
+// still running after it fails the test.
+const cmdTimeout = 2 * time.Second
+
+// maxDepth bounds chains of commands returning messages that return commands.
+const maxDepth = 32
+
+// Driver feeds messages to a model the way the Bubble Tea runtime would and
+// runs the commands it returns. Commands in a batch run concurrently, but
+// their messages are delivered in batch order so every run renders the same.
+type Driver struct {
+	t      testing.TB
+	model  tea.Model
+	ignore []func(tea.Msg) bool
+	quit   bool
+}
+
+// New creates a driver for model. Spinner ticks are always ignored.
+func New(t testing.TB, model tea.Model) *Driver {
+	t.Helper()
+	d := &Driver{t: t, model: model}
+	return d.Ignore(func(msg tea.Msg) bool {
+		_, ok := msg.(spinner.TickMsg)
+		return ok
+	})
+}
+
+// Ignore drops messages matching drop instead of delivering them, e.g. ticks
+// that would otherwise reschedule themselves forever.
+func (d *Driver) Ignore(drop func(tea.Msg) bool) *Driver {
+	d.ignore = append(d.ignore, drop)
+	return d
+}
+
+// Init runs the model's Init command.
+func (d *Driver) Init() *Driver {
+	d.t.Helper()
+	d.run(d.model.Init(), 0)
+	return d
+}
+
+// Resize sends a window size message.
+func (d *Driver) Resize(width, height int) *Driver {
+	d.t.Helper()
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Send delivers msgs in order, running the commands each one returns.
+func (d *Driver) Send(msgs ...tea.Msg) *Driver {
+	d.t.Helper()
+	for _, msg := range msgs {
+		d.deliver(msg, 0)
+	}
+	return d
+}
+
+// Keys presses each key, named as tea.KeyMsg.String reports it, e.g. "tab",
+// "ctrl+c", " " or "j".
+func (d *Driver) Keys(keys ...string) *Driver {
+	d.t.Helper()
+	for _, k := range keys {
+		d.Send(Key(k))
+	}
+	return d
+}
+
+// Type types text one rune at a time.
+func (d *Driver) Type(text string) *Driver {
+	d.t.Helper()
+	for _, r := range text {
+		d.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return d
+}
+
+// Model returns the current model.
+func (d *Driver) Model() tea.Model {
+	return d.model
+}
+
+// Quit reports whether the model asked to quit.
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// View renders the current model.
+func (d *Driver) View() string {
+	return d.model.View()
+}
+
+// Golden compares the current view with the golden file name.
+func (d *Driver) Golden(name string) *Driver {
+	d.t.Helper()
+	Golden(d.t, name, d.View())
+	return d
+}
+
+func (d *Driver) deliver(msg tea.Msg, depth int) {
+	d.t.Helper()
+
+	if msg == nil || d.quit {
+		return
+	}
+	if _, ok := msg.(tea.QuitMsg); ok {
+		d.quit = true
+		return
+	}
+	if cmds, ok := batch(msg); ok {
+		d.runAll(cmds, depth)
+		return
+	}
+	for _, drop := range d.ignore {
+		if drop(msg) {
+			return
+		}
+	}
+
+	var cmd tea.Cmd
+	d.model, cmd = d.model.Update(msg)
+	d.run(cmd, depth+1)
+}
+
+func (d *Driver) run(cmd tea.Cmd, depth int) {
+	d.t.Helper()
+	if cmd != nil {
+		d.runAll([]tea.Cmd{cmd}, depth)
+	}
+}
+
+// runAll runs cmds concurrently and delivers their messages in order.
+func (d *Driver) runAll(cmds []tea.Cmd, depth int) {
+	d.t.Helper()
+
+	if depth > maxDepth {
+		d.t.Fatalf("commands still producing messages after %d rounds", maxDepth)
+	}
+
+	// Each command writes its own slot, read once all have finished
+	msgs := make([]tea.Msg, len(cmds))
+	var wg sync.WaitGroup
+	for i, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msgs[i] = cmd()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(cmdTimeout):
+		d.t.Fatalf("command still running after %v; pin the clock or stub the slow call", cmdTimeout)
+	}
+
+	for _, msg := range msgs {
+		d.deliver(msg, depth)
+	}
+}
+
+var cmdSliceType = reflect.TypeFor[[]tea.Cmd]()
+
+// batch unpacks the messages tea.Batch and tea.Sequence produce.
+func batch(msg tea.Msg) ([]tea.Cmd, bool) {
+	if cmds, ok := msg.(tea.BatchMsg); ok {
+		return cmds, true
+	}
+	v := reflect.ValueOf(msg)
+	if v.Kind() == reflect.Slice && v.Type().ConvertibleTo(cmdSliceType) {
+		return v.Convert(cmdSliceType).Interface().([]tea.Cmd), true
+	}
+	return nil, false
+}
+
+// Key returns the key message for a key named as tea.KeyMsg.String reports
+// it.
+func Key(name string) tea.KeyMsg {
+	if t, ok := keyTypes[name]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// keyTypes maps key names to key types. Where several types share a name,
+// e.g. "enter" and ctrl+m, the lowest wins.
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyType(-200); t < 128; t++ {
+		name := t.String()
+		if _, seen := types[name]; name != "" && name != "runes" && !seen {
+			types[name] = t
+		}
+	}
+	return types
+}()
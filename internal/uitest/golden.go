@@ -0,0 +1,64 @@
+package uitest
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Golden compares got with testdata/<name>.golden, relative to the test's
+// package. With UPDATE_GOLDEN=1 in the environment it rewrites the file
+// instead.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with UPDATE_GOLDEN=1 to create it): %v", err)
+	}
+
+	if got != string(want) {
+		t.Errorf("output does not match %s (run with UPDATE_GOLDEN=1 to update)\n%s", path, diff(string(want), got))
+	}
+}
+
+// diff lists the lines that differ between want and got.
+func diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+
+	var b strings.Builder
+	for i := range max(len(wantLines), len(gotLines)) {
+		var w, g string
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			b.WriteString("line ")
+			b.WriteString(strconv.Itoa(i + 1))
+			b.WriteString(":\n  want: ")
+			b.WriteString(w)
+			b.WriteString("\n  got:  ")
+			b.WriteString(g)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
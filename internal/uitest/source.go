@@ -0,0 +1,146 @@
+package uitest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+	"github.com/HatiCode/kedastral-tui/clock"
+	"github.com/HatiCode/kedastral-tui/source"
+)
+
+// Now is the time the pinned clock reports.
+var Now = time.Date(2026, time.March, 14, 9, 30, 0, 0, time.UTC)
+
+// pinned counts the tests holding the clock.
+var pinned atomic.Int32
+
+// PinClock stops the clock at Now, in UTC, for the rest of the test. Timers
+// on the clock fire at once. The clock is shared by the whole package, so a
+// test that pins it must not run in parallel with another that does.
+func PinClock(t testing.TB) {
+	t.Helper()
+	if pinned.Add(1) > 1 {
+		pinned.Add(-1)
+		t.Fatalf("clock already pinned by a parallel test")
+	}
+
+	clock.Set(func() time.Time { return Now }, time.UTC)
+	t.Cleanup(func() {
+		clock.Reset()
+		pinned.Add(-1)
+	})
+}
+
+// Snapshot returns a 30 minute v2 forecast for workload, generated a minute
+// before Now, whose P50 rises from 100 to a peak of 220 and falls back.
+func Snapshot(workload string) client.QuantileSnapshot {
+	const steps = 30
+
+	p10 := make([]float64, steps)
+	p50 := make([]float64, steps)
+	p90 := make([]float64, steps)
+	desired := make([]int, steps)
+	for i := range steps {
+		p50[i] = math.Round(100 + 120*math.Sin(math.Pi*float64(i)/(steps-1)))
+		p10[i] = math.Round(p50[i] * (0.9 - 0.01*float64(i)))
+		p90[i] = math.Round(p50[i] * (1.1 + 0.01*float64(i)))
+		desired[i] = int(math.Ceil(p50[i] / 60))
+	}
+
+	return client.QuantileSnapshot{
+		Workload:        workload,
+		Metric:          "http_requests_per_second",
+		GeneratedAt:     Now.Add(-time.Minute),
+		StepSeconds:     60,
+		HorizonSeconds:  steps * 60,
+		Quantiles:       map[string][]float64{"p10": p10, "p50": p50, "p90": p90},
+		Values:          p50,
+		DesiredReplicas: desired,
+	}
+}
+
+// ScalerMetrics is scaler /metrics output for the workloads "checkout" and
+// "search".
+const ScalerMetrics = `# TYPE kedastral_scaler_desired_replicas_returned gauge
+kedastral_scaler_desired_replicas_returned{workload="checkout"} 3
+kedastral_scaler_desired_replicas_returned{workload="search"} 2
+# TYPE kedastral_scaler_forecast_age_seen_seconds gauge
+kedastral_scaler_forecast_age_seen_seconds{workload="checkout"} 12.5
+kedastral_scaler_forecast_age_seen_seconds{workload="search"} 40
+# TYPE kedastral_scaler_grpc_requests_total counter
+kedastral_scaler_grpc_requests_total{workload="checkout",status="active"} 120
+kedastral_scaler_grpc_requests_total{workload="checkout",status="error"} 2
+kedastral_scaler_grpc_requests_total{workload="search",status="active"} 80
+`
+
+// Source is a ForecastSource serving fixed data. Set an error field to make
+// that call fail.
+type Source struct {
+	Snapshots     map[string]client.QuantileSnapshot
+	Workloads     []client.WorkloadInfo
+	ScalerMetrics string // Prometheus text format
+
+	Stale         bool
+	SnapshotErr   error
+	ScalerErr     error
+	ForecasterErr error
+}
+
+var _ source.ForecastSource = (*Source)(nil)
+
+// NewSource returns a source serving Snapshot and ScalerMetrics for the
+// workloads "checkout" and "search".
+func NewSource() *Source {
+	return &Source{
+		Snapshots: map[string]client.QuantileSnapshot{
+			"checkout": Snapshot("checkout"),
+			"search":   Snapshot("search"),
+		},
+		Workloads: []client.WorkloadInfo{
+			{Name: "checkout", Namespace: "shop", LastForecast: Now.Add(-time.Minute), Healthy: true, CurrentReplicas: 2},
+			{Name: "search", Namespace: "shop", LastForecast: Now.Add(-20 * time.Minute), Healthy: false, CurrentReplicas: 1},
+		},
+		ScalerMetrics: ScalerMetrics,
+	}
+}
+
+func (s *Source) GetQuantileSnapshot(_ context.Context, workload string, leadTime time.Duration) (*client.QuantileSnapshotData, error) {
+	if s.SnapshotErr != nil {
+		return nil, s.SnapshotErr
+	}
+	snapshot, ok := s.Snapshots[workload]
+	if !ok {
+		return nil, fmt.Errorf("forecaster returned status 404: unknown workload %s", workload)
+	}
+	return client.NewQuantileSnapshotData(snapshot, s.Stale, leadTime), nil
+}
+
+func (s *Source) GetWorkloads(context.Context) ([]client.WorkloadInfo, error) {
+	return s.Workloads, nil
+}
+
+func (s *Source) GetScalerMetrics(_ context.Context, workload string) (*client.ScalerMetrics, error) {
+	if s.ScalerErr != nil {
+		return nil, s.ScalerErr
+	}
+	set, err := promtext.Parse(strings.NewReader(s.ScalerMetrics))
+	if err != nil {
+		return nil, err
+	}
+	return client.NewScalerMetrics(set, workload), nil
+}
+
+func (s *Source) CheckForecasterHealth(context.Context) error {
+	return s.ForecasterErr
+}
+
+func (s *Source) CheckScalerHealth(context.Context) error {
+	return s.ScalerErr
+}
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/HatiCode/kedastral-tui/cli"
 	"github.com/HatiCode/kedastral-tui/client"
 	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/session"
+	"github.com/HatiCode/kedastral-tui/source"
 	"github.com/HatiCode/kedastral-tui/ui"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -13,6 +20,10 @@ import (
 var version = "dev"
 
 func main() {
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1], os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	for _, arg := range os.Args[1:] {
 		if arg == "--version" || arg == "-version" {
 			fmt.Printf("kedastral-tui %s\n", version)
@@ -20,6 +31,16 @@ func main() {
 		}
 	}
 
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintln(out, "Usage: kedastral-tui [command] [flags]")
+		fmt.Fprintln(out, "\nWithout a command, starts the interactive TUI.")
+		fmt.Fprintln(out)
+		cli.Usage(out)
+		fmt.Fprintln(out, "\nFlags:")
+		flag.PrintDefaults()
+	}
+
 	cfg, needsSetup := config.ParseFlags()
 
 	if needsSetup {
@@ -51,17 +72,90 @@ func main() {
 		if newCfg.LogLevel == "" {
 			newCfg.LogLevel = cfg.LogLevel
 		}
+		if newCfg.HistorySize == 0 {
+			newCfg.HistorySize = cfg.HistorySize
+		}
+		if newCfg.PrometheusURL == "" {
+			newCfg.PrometheusURL = cfg.PrometheusURL
+		}
+		if newCfg.ObservedQuery == "" {
+			newCfg.ObservedQuery = cfg.ObservedQuery
+		}
+		if newCfg.Retries == nil {
+			newCfg.Retries = cfg.Retries
+		}
+		if newCfg.RetryBackoff == 0 {
+			newCfg.RetryBackoff = cfg.RetryBackoff
+		}
+		if newCfg.BreakerThreshold == nil {
+			newCfg.BreakerThreshold = cfg.BreakerThreshold
+		}
+		if newCfg.BreakerCooldown == 0 {
+			newCfg.BreakerCooldown = cfg.BreakerCooldown
+		}
+		if newCfg.ChartYLabels == 0 {
+			newCfg.ChartYLabels = cfg.ChartYLabels
+		}
+		if newCfg.HighlightQuantile == "" {
+			newCfg.HighlightQuantile = cfg.HighlightQuantile
+		}
+		if !newCfg.Stream {
+			newCfg.Stream = cfg.Stream
+		}
+		newCfg.Record = cfg.Record
+		newCfg.ReplaySpeed = cfg.ReplaySpeed
 
 		cfg = newCfg
 	}
 
-	c := client.New(cfg.ForecasterURL, cfg.ScalerURL)
+	src, err := source.New(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cfg.Demo || cfg.ForecastFile != "" {
+		// Offline sources list their own workloads, so --workload is optional
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		workloads, err := src.GetWorkloads(ctx)
+		cancel()
+		if err == nil && len(workloads) > 0 && !slices.ContainsFunc(workloads, func(w client.WorkloadInfo) bool {
+			return w.Name == cfg.Workload
+		}) {
+			cfg.Workload = workloads[0].Name
+		}
+	}
+
+	model := ui.NewModel(cfg, src)
+
+	if cfg.Replay != "" {
+		records, err := session.Load(cfg.Replay)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		model = model.WithReplay(records, cfg.ReplaySpeed)
+	}
 
-	model := ui.NewModel(cfg, c)
+	var recorder *session.Recorder
+	if cfg.Record != "" {
+		recorder, err = session.NewRecorder(cfg.Record)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		model = model.WithRecorder(recorder)
+	}
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	if recorder != nil {
+		if closeErr := recorder.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
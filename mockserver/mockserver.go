@@ -0,0 +1,218 @@
+// Package mockserver serves fake Kedastral forecaster and scaler APIs backed
+// by synthetic data, with scenarios for the edge cases the client handles.
+package mockserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/source"
+)
+
+// Scenario controls how the mock endpoints respond.
+type Scenario struct {
+	APIVersion int           // 1 serves values only, 2 adds quantiles
+	Stale      bool          // Mark forecasts stale with X-Kedastral-Stale
+	NotFound   []string      // Listed workloads whose forecast returns 404
+	Latency    time.Duration // Delay before every response
+	ErrorRate  float64       // Fraction of requests answered with 500
+}
+
+// Scenarios are the named presets for the mock-server command.
+var Scenarios = map[string]Scenario{
+	"healthy": {APIVersion: 2},
+	"v1":      {APIVersion: 1},
+	"stale":   {APIVersion: 2, Stale: true},
+	"slow":    {APIVersion: 2, Latency: 3 * time.Second},
+	"flaky":   {APIVersion: 2, ErrorRate: 0.3},
+	"down":    {APIVersion: 2, ErrorRate: 1},
+}
+
+// ScenarioNames returns the preset names, sorted.
+func ScenarioNames() []string {
+	names := make([]string, 0, len(Scenarios))
+	for name := range Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Server generates forecasts for its workloads and serves them under a
+// scenario.
+type Server struct {
+	scenario  Scenario
+	workloads []string
+	data      *source.Synthetic
+}
+
+// New creates a server for the named workloads.
+func New(scenario Scenario, workloads ...string) *Server {
+	return &Server{
+		scenario:  scenario,
+		workloads: workloads,
+		data:      source.NewSynthetic(workloads...),
+	}
+}
+
+// Forecaster returns the handler for the forecaster API.
+func (s *Server) Forecaster() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /forecast/current", s.handleForecast)
+	mux.HandleFunc("GET /forecasts/workloads", s.handleWorkloads)
+	mux.HandleFunc("GET /forecast/actuals", s.handleActuals)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	return s.misbehave(mux)
+}
+
+// Scaler returns the handler for the scaler's HTTP endpoints.
+func (s *Server) Scaler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	return s.misbehave(mux)
+}
+
+// misbehave applies the scenario's latency and error rate to every request.
+func (s *Server) misbehave(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.scenario.Latency > 0 {
+			timer := time.NewTimer(s.scenario.Latency)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		if s.scenario.ErrorRate > 0 && rand.Float64() < s.scenario.ErrorRate {
+			http.Error(w, "mock: simulated internal error", http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleForecast(w http.ResponseWriter, r *http.Request) {
+	workload := r.URL.Query().Get("workload")
+	if !slices.Contains(s.workloads, workload) || slices.Contains(s.scenario.NotFound, workload) {
+		http.Error(w, fmt.Sprintf("no forecast for workload %q", workload), http.StatusNotFound)
+		return
+	}
+
+	data, err := s.data.GetQuantileSnapshot(r.Context(), workload, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if s.scenario.Stale {
+		w.Header().Set("X-Kedastral-Stale", "true")
+	}
+
+	snapshot := data.Snapshot
+	if s.scenario.APIVersion == 1 {
+		writeJSON(w, client.Snapshot{
+			Workload:        snapshot.Workload,
+			Metric:          snapshot.Metric,
+			GeneratedAt:     snapshot.GeneratedAt,
+			StepSeconds:     snapshot.StepSeconds,
+			HorizonSeconds:  snapshot.HorizonSeconds,
+			Values:          snapshot.Values,
+			DesiredReplicas: snapshot.DesiredReplicas,
+		})
+		return
+	}
+	writeJSON(w, snapshot)
+}
+
+func (s *Server) handleWorkloads(w http.ResponseWriter, r *http.Request) {
+	workloads, err := s.data.GetWorkloads(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, struct {
+		Workloads []client.WorkloadInfo `json:"workloads"`
+	}{workloads})
+}
+
+func (s *Server) handleActuals(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	workload := query.Get("workload")
+	start, errStart := time.Parse(time.RFC3339, query.Get("start"))
+	end, errEnd := time.Parse(time.RFC3339, query.Get("end"))
+	step, errStep := time.ParseDuration(query.Get("step"))
+	if errStart != nil || errEnd != nil || errStep != nil {
+		http.Error(w, "start, end and step are required", http.StatusBadRequest)
+		return
+	}
+
+	series, err := s.data.GetObservedValues(r.Context(), workload, "", start, end, step)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, series)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	text, err := s.data.ScalerMetricsText()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, text)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ListenAndServe serves the forecaster on forecasterAddr and the scaler on
+// scalerAddr until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, forecasterAddr, scalerAddr string) error {
+	servers := []*http.Server{
+		{Addr: forecasterAddr, Handler: s.Forecaster(), ReadHeaderTimeout: 5 * time.Second},
+		{Addr: scalerAddr, Handler: s.Scaler(), ReadHeaderTimeout: 5 * time.Second},
+	}
+
+	errs := make(chan error, len(servers))
+	for _, srv := range servers {
+		go func() {
+			if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+				errs <- fmt.Errorf("failed to serve on %s: %w", srv.Addr, err)
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-errs:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for _, srv := range servers {
+		srv.Shutdown(shutdownCtx)
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+package mockserver_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/mockserver"
+)
+
+func newClient(t *testing.T, scenario mockserver.Scenario) *client.Client {
+	t.Helper()
+
+	srv := mockserver.New(scenario, "checkout", "search")
+	forecaster := httptest.NewServer(srv.Forecaster())
+	scaler := httptest.NewServer(srv.Scaler())
+	t.Cleanup(forecaster.Close)
+	t.Cleanup(scaler.Close)
+
+	return client.New(forecaster.URL, scaler.URL,
+		client.WithRetry(client.RetryPolicy{}),
+		client.WithCircuitBreaker(0, 0),
+	)
+}
+
+func TestScenarios(t *testing.T) {
+	tests := []struct {
+		name       string
+		scenario   mockserver.Scenario
+		workload   string
+		apiVersion int
+		stale      bool
+		wantErr    string
+	}{
+		{name: "v2", scenario: mockserver.Scenarios["healthy"], workload: "checkout", apiVersion: 2},
+		{name: "v1", scenario: mockserver.Scenarios["v1"], workload: "checkout", apiVersion: 1},
+		{name: "stale", scenario: mockserver.Scenarios["stale"], workload: "search", apiVersion: 2, stale: true},
+		{name: "unknown workload", scenario: mockserver.Scenarios["healthy"], workload: "billing", wantErr: "status 404"},
+		{name: "not found", scenario: mockserver.Scenario{APIVersion: 2, NotFound: []string{"search"}}, workload: "search", wantErr: "status 404"},
+		{name: "down", scenario: mockserver.Scenarios["down"], workload: "checkout", wantErr: "status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, tt.scenario)
+
+			data, err := c.GetQuantileSnapshot(context.Background(), tt.workload, 5*time.Minute)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetQuantileSnapshot() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetQuantileSnapshot() error = %v", err)
+			}
+			if data.APIVersion != tt.apiVersion {
+				t.Errorf("APIVersion = %d, want %d", data.APIVersion, tt.apiVersion)
+			}
+			if data.Stale != tt.stale {
+				t.Errorf("Stale = %v, want %v", data.Stale, tt.stale)
+			}
+			if len(data.Snapshot.Values) == 0 {
+				t.Error("snapshot has no values")
+			}
+		})
+	}
+}
+
+func TestSlowScenarioRespectsDeadline(t *testing.T) {
+	c := newClient(t, mockserver.Scenario{APIVersion: 2, Latency: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.GetQuantileSnapshot(ctx, "checkout", 0); err == nil {
+		t.Fatal("GetQuantileSnapshot() succeeded, want deadline error")
+	}
+}
+
+func TestWorkloadsAndScaler(t *testing.T) {
+	c := newClient(t, mockserver.Scenarios["healthy"])
+	ctx := context.Background()
+
+	workloads, err := c.GetWorkloads(ctx)
+	if err != nil {
+		t.Fatalf("GetWorkloads() error = %v", err)
+	}
+	if len(workloads) != 2 {
+		t.Errorf("GetWorkloads() returned %d workloads, want 2", len(workloads))
+	}
+
+	if _, err := c.GetScalerMetrics(ctx, "checkout"); err != nil {
+		t.Errorf("GetScalerMetrics() error = %v", err)
+	}
+	if err := c.CheckForecasterHealth(ctx); err != nil {
+		t.Errorf("CheckForecasterHealth() error = %v", err)
+	}
+	if err := c.CheckScalerHealth(ctx); err != nil {
+		t.Errorf("CheckScalerHealth() error = %v", err)
+	}
+}
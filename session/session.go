@@ -0,0 +1,124 @@
+// Package session records the data received during a TUI session to a JSONL
+// file and loads it back for replay.
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
+)
+
+// Kind identifies what a record holds.
+type Kind string
+
+const (
+	KindSnapshot      Kind = "snapshot"
+	KindScalerMetrics Kind = "scaler_metrics"
+	KindHealth        Kind = "health"
+	KindWorkloads     Kind = "workloads"
+)
+
+// Endpoints reported by health records.
+const (
+	EndpointForecaster = "forecaster"
+	EndpointScaler     = "scaler"
+)
+
+// Record is one result received from the forecaster or scaler. Error is set
+// when the request failed, in which case the data field is empty.
+type Record struct {
+	Time      time.Time                    `json:"time"`
+	Kind      Kind                         `json:"kind"`
+	Workload  string                       `json:"workload,omitempty"`
+	Endpoint  string                       `json:"endpoint,omitempty"`
+	Snapshot  *client.QuantileSnapshotData `json:"snapshot,omitempty"`
+	Metrics   *client.ScalerMetrics        `json:"metrics,omitempty"`
+	Workloads []client.WorkloadInfo        `json:"workloads,omitempty"`
+	Error     string                       `json:"error,omitempty"`
+}
+
+// Recorder appends records to a JSONL file.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewRecorder creates or truncates the file at path.
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	return &Recorder{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Write appends a record, stamping it with the current time if unset.
+func (r *Recorder) Write(rec Record) error {
+	if rec.Time.IsZero() {
+		rec.Time = clock.Now()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.enc.Encode(rec); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
+
+// Close closes the file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// Load reads every record from a JSONL file, sorted by time.
+func Load(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse recording line %d: %w", lineNo, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("recording %s is empty", path)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	return records, nil
+}
@@ -0,0 +1,44 @@
+package source
+
+import (
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/config"
+)
+
+// NewClient creates the forecaster and scaler API client for cfg.
+func NewClient(cfg *config.Config) (*client.Client, error) {
+	retry := client.RetryPolicy{
+		MaxRetries: client.DefaultRetryPolicy.MaxRetries,
+		BaseDelay:  cfg.RetryBackoff,
+		MaxDelay:   client.DefaultRetryPolicy.MaxDelay,
+	}
+	if cfg.Retries != nil {
+		retry.MaxRetries = *cfg.Retries
+	}
+	threshold := client.DefaultBreakerThreshold
+	if cfg.BreakerThreshold != nil {
+		threshold = *cfg.BreakerThreshold
+	}
+
+	c := client.New(cfg.ForecasterURL, cfg.ScalerURL,
+		client.WithPrometheus(cfg.PrometheusURL, cfg.ObservedQuery),
+		client.WithForecasterAuth(authFromConfig(cfg.ForecasterAuth)),
+		client.WithScalerAuth(authFromConfig(cfg.ScalerAuth)),
+		client.WithPrometheusAuth(authFromConfig(cfg.PrometheusAuth)),
+		client.WithRetry(retry),
+		client.WithCircuitBreaker(threshold, cfg.BreakerCooldown),
+	)
+	return c, c.Err()
+}
+
+func authFromConfig(a config.AuthConfig) client.Auth {
+	return client.Auth{
+		BearerToken:     a.BearerToken,
+		BearerTokenFile: a.BearerTokenFile,
+		Username:        a.Username,
+		Password:        a.Password,
+		CertFile:        a.CertFile,
+		KeyFile:         a.KeyFile,
+		CAFile:          a.CAFile,
+	}
+}
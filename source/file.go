@@ -0,0 +1,127 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+)
+
+// fileData is the format read by NewFile. ScalerMetrics holds the scaler's
+// /metrics output in the Prometheus text format.
+type fileData struct {
+	Workloads     []client.WorkloadInfo     `json:"workloads,omitempty"`
+	Snapshots     []client.QuantileSnapshot `json:"snapshots"`
+	ScalerMetrics string                    `json:"scalerMetrics,omitempty"`
+}
+
+// File serves snapshots loaded from a JSON file. Forecasts age as usual, so a
+// file captured earlier shows how old its forecasts are.
+type File struct {
+	workloads []client.WorkloadInfo
+	snapshots map[string]client.QuantileSnapshot
+	metrics   *promtext.Set
+}
+
+// NewFile loads a file holding either a single /forecast/current response or
+// an object with "snapshots", and optionally "workloads" and
+// "scalerMetrics".
+func NewFile(path string) (*File, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read forecast file: %w", err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse forecast file: %w", err)
+	}
+
+	var data fileData
+	if _, ok := fields["snapshots"]; ok {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("failed to parse forecast file: %w", err)
+		}
+	} else {
+		var snapshot client.QuantileSnapshot
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to parse forecast file: %w", err)
+		}
+		data.Snapshots = []client.QuantileSnapshot{snapshot}
+	}
+
+	if len(data.Snapshots) == 0 {
+		return nil, fmt.Errorf("forecast file %s has no snapshots", path)
+	}
+
+	f := &File{
+		workloads: data.Workloads,
+		snapshots: make(map[string]client.QuantileSnapshot, len(data.Snapshots)),
+	}
+	for _, s := range data.Snapshots {
+		f.snapshots[s.Workload] = s
+	}
+
+	if data.ScalerMetrics != "" {
+		f.metrics, err = promtext.Parse(strings.NewReader(data.ScalerMetrics))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse scaler metrics: %w", err)
+		}
+	}
+
+	if len(f.workloads) == 0 {
+		for name, s := range f.snapshots {
+			f.workloads = append(f.workloads, client.WorkloadInfo{
+				Name:         name,
+				LastForecast: s.GeneratedAt,
+				Healthy:      true,
+			})
+		}
+		sort.Slice(f.workloads, func(i, j int) bool {
+			return f.workloads[i].Name < f.workloads[j].Name
+		})
+	}
+
+	return f, nil
+}
+
+// GetQuantileSnapshot returns the file's snapshot for workload.
+func (f *File) GetQuantileSnapshot(_ context.Context, workload string, leadTime time.Duration) (*client.QuantileSnapshotData, error) {
+	snapshot, ok := f.snapshots[workload]
+	if !ok {
+		return nil, fmt.Errorf("no snapshot for workload %q", workload)
+	}
+	return client.NewQuantileSnapshotData(snapshot, false, leadTime), nil
+}
+
+// GetWorkloads returns the file's workloads.
+func (f *File) GetWorkloads(context.Context) ([]client.WorkloadInfo, error) {
+	return f.workloads, nil
+}
+
+// GetScalerMetrics returns the file's scaler metrics for workload.
+func (f *File) GetScalerMetrics(_ context.Context, workload string) (*client.ScalerMetrics, error) {
+	if f.metrics == nil {
+		return nil, fmt.Errorf("scaler metrics: %w", ErrUnavailable)
+	}
+	return client.NewScalerMetrics(f.metrics, workload), nil
+}
+
+// CheckForecasterHealth always succeeds.
+func (f *File) CheckForecasterHealth(context.Context) error {
+	return nil
+}
+
+// CheckScalerHealth succeeds if the file has scaler metrics.
+func (f *File) CheckScalerHealth(context.Context) error {
+	if f.metrics == nil {
+		return fmt.Errorf("scaler: %w", ErrUnavailable)
+	}
+	return nil
+}
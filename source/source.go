@@ -0,0 +1,65 @@
+// Package source defines where the TUI reads its data from. *client.Client
+// is the HTTP source; File and Synthetic serve data without a Kedastral
+// deployment.
+package source
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/config"
+)
+
+// ErrUnavailable is returned for data a source does not provide.
+var ErrUnavailable = errors.New("not provided by this source")
+
+// ForecastSource provides the forecasts, workloads, scaler metrics and health
+// shown by the TUI.
+type ForecastSource interface {
+	GetQuantileSnapshot(ctx context.Context, workload string, leadTime time.Duration) (*client.QuantileSnapshotData, error)
+	GetWorkloads(ctx context.Context) ([]client.WorkloadInfo, error)
+	GetScalerMetrics(ctx context.Context, workload string) (*client.ScalerMetrics, error)
+	CheckForecasterHealth(ctx context.Context) error
+	CheckScalerHealth(ctx context.Context) error
+}
+
+// Streamer is implemented by sources that push snapshots as they are
+// generated.
+type Streamer interface {
+	StreamQuantileSnapshots(ctx context.Context, workload string, leadTime time.Duration, fn func(*client.QuantileSnapshotData)) error
+}
+
+// ObservedSource is implemented by sources that report observed metric
+// values.
+type ObservedSource interface {
+	GetObservedValues(ctx context.Context, workload, metric string, start, end time.Time, step time.Duration) (*client.ObservedSeries, error)
+}
+
+// BreakerReporter is implemented by sources whose endpoints are guarded by
+// circuit breakers.
+type BreakerReporter interface {
+	BreakerStatuses() []client.BreakerStatus
+}
+
+var (
+	_ ForecastSource  = (*client.Client)(nil)
+	_ ObservedSource  = (*Synthetic)(nil)
+	_ Streamer        = (*client.Client)(nil)
+	_ ObservedSource  = (*client.Client)(nil)
+	_ BreakerReporter = (*client.Client)(nil)
+)
+
+// New returns the source selected by cfg: the demo generator, a static file,
+// or the forecaster and scaler APIs.
+func New(cfg *config.Config) (ForecastSource, error) {
+	switch {
+	case cfg.Demo:
+		return NewDemo(), nil
+	case cfg.ForecastFile != "":
+		return NewFile(cfg.ForecastFile)
+	default:
+		return NewClient(cfg)
+	}
+}
@@ -0,0 +1,306 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+	"github.com/HatiCode/kedastral-tui/clock"
+)
+
+// Synthetic forecast shape, matching the forecaster's defaults.
+const (
+	syntheticStep    = time.Minute
+	syntheticHorizon = 30 * time.Minute
+	syntheticMetric  = "http_requests_per_second"
+)
+
+// DemoWorkloads are the workloads served by NewDemo.
+var DemoWorkloads = []string{"checkout", "search", "payments", "recommendations", "notifications"}
+
+// Demo scenario. Each workload's forecasts stall for demoStaleFor once every
+// demoStaleEvery, and the scaler drops out for demoOutageFor once every
+// demoOutageEvery, so a demo shows every state within a few minutes.
+const (
+	demoNoise       = 0.08
+	demoStaleEvery  = 12 * time.Minute
+	demoStaleFor    = 3 * time.Minute
+	demoOutageEvery = 10 * time.Minute
+	demoOutageFor   = time.Minute
+)
+
+// errScalerOutage is returned by a Synthetic source during a scaler outage.
+var errScalerOutage = errors.New("scaler unreachable (simulated outage)")
+
+// Synthetic generates forecasts following a daily traffic cycle. Each
+// workload gets its own level, amplitude and peak hour derived from its name,
+// so the same workload always looks the same.
+type Synthetic struct {
+	workloads []syntheticWorkload
+	started   time.Time
+	now       func() time.Time
+
+	noise       float64 // Relative standard deviation of traffic around the cycle
+	staleEvery  time.Duration
+	staleFor    time.Duration
+	outageEvery time.Duration
+	outageFor   time.Duration
+}
+
+type syntheticWorkload struct {
+	name       string
+	seed       uint64
+	base       float64       // Mean traffic over a day
+	amplitude  float64       // Swing around base, as a fraction of it
+	peak       float64       // Hour of day with the most traffic
+	perReplica float64       // Traffic one replica handles
+	offset     time.Duration // Shifts the stale periods between workloads
+}
+
+// NewSynthetic creates a synthetic source for the named workloads.
+func NewSynthetic(workloads ...string) *Synthetic {
+	s := &Synthetic{now: clock.Now}
+	s.started = s.now()
+
+	for i, name := range workloads {
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		seed := h.Sum64()
+
+		base := 50 + float64(seed%400)
+		s.workloads = append(s.workloads, syntheticWorkload{
+			name:       name,
+			seed:       seed,
+			base:       base,
+			amplitude:  0.3 + float64(seed>>8%40)/100,
+			peak:       float64(seed >> 16 % 24),
+			perReplica: math.Max(10, base/float64(2+seed>>24%6)),
+			offset:     time.Duration(i) * 2 * time.Minute,
+		})
+	}
+
+	return s
+}
+
+// NewDemo creates a synthetic source for DemoWorkloads with noisy traffic,
+// stale forecasts and scaler outages.
+func NewDemo() *Synthetic {
+	s := NewSynthetic(DemoWorkloads...)
+	s.noise = demoNoise
+	s.staleEvery, s.staleFor = demoStaleEvery, demoStaleFor
+	s.outageEvery, s.outageFor = demoOutageEvery, demoOutageFor
+	return s
+}
+
+func (s *Synthetic) workload(name string) (syntheticWorkload, error) {
+	for _, w := range s.workloads {
+		if w.name == name {
+			return w, nil
+		}
+	}
+	return syntheticWorkload{}, fmt.Errorf("unknown workload %q", name)
+}
+
+// traffic returns the expected traffic at t: a daily cycle with a smaller
+// second peak twelve hours after the main one.
+func (w syntheticWorkload) traffic(t time.Time) float64 {
+	hour := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	phase := 2 * math.Pi * (hour - w.peak) / 24
+	return w.base * (1 + w.amplitude*(math.Cos(phase)+0.25*math.Cos(2*phase)))
+}
+
+// replicas returns the replicas needed for traffic.
+func (w syntheticWorkload) replicas(traffic float64) int {
+	return max(1, int(math.Ceil(traffic/w.perReplica)))
+}
+
+// rng returns a generator that always yields the same values for a workload
+// at t, so repeated requests within a step agree.
+func (w syntheticWorkload) rng(t time.Time, salt uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(w.seed^salt, uint64(t.Unix())))
+}
+
+// within reports whether elapsed falls in the last window of every period,
+// and if so how long ago that window began.
+func within(elapsed, every, window time.Duration) (time.Duration, bool) {
+	if every <= 0 || window <= 0 {
+		return 0, false
+	}
+	pos := elapsed % every
+	if pos < every-window {
+		return 0, false
+	}
+	return pos - (every - window), true
+}
+
+// staleSince returns when the forecasts of w stopped being refreshed, if
+// they are stale at now.
+func (s *Synthetic) staleSince(w syntheticWorkload, now time.Time) (time.Time, bool) {
+	ago, stale := within(now.Sub(s.started)+w.offset, s.staleEvery, s.staleFor)
+	return now.Add(-ago), stale
+}
+
+func (s *Synthetic) scalerDown(now time.Time) bool {
+	_, down := within(now.Sub(s.started), s.outageEvery, s.outageFor)
+	return down
+}
+
+// GetQuantileSnapshot generates a forecast made at the start of the current
+// step, or of the step the forecasts went stale in. The quantile spread
+// widens with distance into the horizon.
+func (s *Synthetic) GetQuantileSnapshot(_ context.Context, workload string, leadTime time.Duration) (*client.QuantileSnapshotData, error) {
+	w, err := s.workload(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	generated := now.Truncate(syntheticStep)
+	since, stale := s.staleSince(w, now)
+	if stale {
+		generated = since.Truncate(syntheticStep)
+	}
+
+	steps := int(syntheticHorizon / syntheticStep)
+	rng := w.rng(generated, 1)
+
+	p10 := make([]float64, steps)
+	p50 := make([]float64, steps)
+	p90 := make([]float64, steps)
+	desired := make([]int, steps)
+
+	for i := range steps {
+		// The forecast misses the true cycle by about half the traffic noise
+		p50[i] = w.traffic(generated.Add(time.Duration(i)*syntheticStep)) * (1 + 0.5*s.noise*rng.NormFloat64())
+		spread := 0.05 + 0.25*float64(i)/float64(steps)
+		p10[i] = p50[i] * (1 - spread)
+		p90[i] = p50[i] * (1 + spread)
+		desired[i] = w.replicas(p90[i])
+	}
+
+	snapshot := client.QuantileSnapshot{
+		Workload:        w.name,
+		Metric:          syntheticMetric,
+		GeneratedAt:     generated,
+		StepSeconds:     int(syntheticStep.Seconds()),
+		HorizonSeconds:  int(syntheticHorizon.Seconds()),
+		Quantiles:       map[string][]float64{"p10": p10, "p50": p50, "p90": p90},
+		Values:          p50,
+		DesiredReplicas: desired,
+	}
+
+	data := client.NewQuantileSnapshotData(snapshot, stale, leadTime)
+	data.ForecastAge = now.Sub(generated)
+	return data, nil
+}
+
+// GetWorkloads returns every synthetic workload.
+func (s *Synthetic) GetWorkloads(context.Context) ([]client.WorkloadInfo, error) {
+	now := s.now()
+	workloads := make([]client.WorkloadInfo, 0, len(s.workloads))
+	for _, w := range s.workloads {
+		lastForecast := now.Truncate(syntheticStep)
+		since, stale := s.staleSince(w, now)
+		if stale {
+			lastForecast = since.Truncate(syntheticStep)
+		}
+
+		workloads = append(workloads, client.WorkloadInfo{
+			Name:            w.name,
+			LastForecast:    lastForecast,
+			Healthy:         !stale,
+			CurrentReplicas: w.replicas(w.traffic(now)),
+		})
+	}
+	return workloads, nil
+}
+
+// GetScalerMetrics parses ScalerMetricsText like the scaler's /metrics.
+func (s *Synthetic) GetScalerMetrics(_ context.Context, workload string) (*client.ScalerMetrics, error) {
+	text, err := s.ScalerMetricsText()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+
+	set, err := promtext.Parse(strings.NewReader(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return client.NewScalerMetrics(set, workload), nil
+}
+
+// ScalerMetricsText renders scaler metrics for every workload in the
+// Prometheus text format, or fails during a scaler outage.
+func (s *Synthetic) ScalerMetricsText() (string, error) {
+	now := s.now()
+	if s.scalerDown(now) {
+		return "", errScalerOutage
+	}
+
+	uptime := now.Sub(s.started).Seconds()
+
+	var b strings.Builder
+	b.WriteString("# TYPE kedastral_scaler_desired_replicas_returned gauge\n")
+	for _, w := range s.workloads {
+		fmt.Fprintf(&b, "kedastral_scaler_desired_replicas_returned{workload=%q} %d\n", w.name, w.replicas(w.traffic(now)))
+	}
+	b.WriteString("# TYPE kedastral_scaler_forecast_age_seen_seconds gauge\n")
+	for _, w := range s.workloads {
+		generated := now.Truncate(syntheticStep)
+		if since, stale := s.staleSince(w, now); stale {
+			generated = since.Truncate(syntheticStep)
+		}
+		fmt.Fprintf(&b, "kedastral_scaler_forecast_age_seen_seconds{workload=%q} %g\n", w.name, now.Sub(generated).Seconds())
+	}
+	b.WriteString("# TYPE kedastral_scaler_grpc_requests_total counter\n")
+	for _, w := range s.workloads {
+		// KEDA polls the scaler every few seconds, and a few calls fail
+		calls := int(uptime/5) + 1
+		fmt.Fprintf(&b, "kedastral_scaler_grpc_requests_total{workload=%q,status=\"active\"} %d\n", w.name, calls)
+		fmt.Fprintf(&b, "kedastral_scaler_grpc_requests_total{workload=%q,status=\"error\"} %d\n", w.name, calls/50)
+	}
+
+	return b.String(), nil
+}
+
+// GetObservedValues returns the traffic that actually happened: the daily
+// cycle plus noise, one sample per step up to now.
+func (s *Synthetic) GetObservedValues(_ context.Context, workload, metric string, start, end time.Time, step time.Duration) (*client.ObservedSeries, error) {
+	w, err := s.workload(workload)
+	if err != nil {
+		return nil, err
+	}
+	if step <= 0 {
+		step = syntheticStep
+	}
+
+	series := &client.ObservedSeries{Workload: workload, Metric: metric}
+	if now := s.now(); end.After(now) {
+		end = now
+	}
+	for t := start.Truncate(step); !t.After(end); t = t.Add(step) {
+		value := w.traffic(t) * (1 + s.noise*w.rng(t, 2).NormFloat64())
+		series.Samples = append(series.Samples, client.Sample{Time: t, Value: math.Max(0, value)})
+	}
+	return series, nil
+}
+
+// CheckForecasterHealth always succeeds.
+func (s *Synthetic) CheckForecasterHealth(context.Context) error {
+	return nil
+}
+
+// CheckScalerHealth fails during scaler outages.
+func (s *Synthetic) CheckScalerHealth(context.Context) error {
+	if s.scalerDown(s.now()) {
+		return errScalerOutage
+	}
+	return nil
+}
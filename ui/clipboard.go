@@ -10,43 +10,47 @@ import (
 )
 
 func (m *Model) copyCurrentTab() error {
+	content, err := m.clipboardText()
+	if err != nil {
+		return err
+	}
+
+	if err := clipboard.WriteAll(content); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+
+	m.toastManager.Add("✓ Copied to clipboard", components.ToastSuccess, 2*time.Second)
+	return nil
+}
+
+// clipboardText returns the active tab's content as copied to the clipboard.
+func (m Model) clipboardText() (string, error) {
 	var content string
 
 	switch m.activeTab {
 	case TabCharts:
-		if m.quantileSnapshot != nil {
+		if snap := m.displaySnapshot(); snap != nil {
 			content = fmt.Sprintf("Workload: %s\n", m.currentWorkload)
-			content += fmt.Sprintf("Forecast Age: %s\n", m.quantileSnapshot.ForecastAge.Round(time.Second))
-			content += fmt.Sprintf("Generated At: %s\n\n", m.quantileSnapshot.Snapshot.GeneratedAt.Format(time.RFC3339))
+			content += fmt.Sprintf("Forecast Age: %s\n", snap.ForecastAge.Round(time.Second))
+			content += fmt.Sprintf("Generated At: %s\n\n", snap.Snapshot.GeneratedAt.Format(time.RFC3339))
 			content += "Quantile Forecast Data:\n"
-			content += fmt.Sprintf("Metric: %s\n", m.quantileSnapshot.Snapshot.Metric)
-			content += fmt.Sprintf("Step: %ds\n", m.quantileSnapshot.Snapshot.StepSeconds)
-			content += fmt.Sprintf("Horizon: %ds\n", m.quantileSnapshot.Snapshot.HorizonSeconds)
-		} else if m.snapshot != nil {
-			content = fmt.Sprintf("Workload: %s\n", m.currentWorkload)
-			content += fmt.Sprintf("Forecast Age: %s\n", m.snapshot.ForecastAge.Round(time.Second))
-			content += fmt.Sprintf("Generated At: %s\n", m.snapshot.Snapshot.GeneratedAt.Format(time.RFC3339))
+			content += fmt.Sprintf("Metric: %s\n", snap.Snapshot.Metric)
+			content += fmt.Sprintf("Step: %ds\n", snap.Snapshot.StepSeconds)
+			content += fmt.Sprintf("Horizon: %ds\n", snap.Snapshot.HorizonSeconds)
+			var keys []string
+			for _, q := range snap.Snapshot.SortedQuantiles() {
+				keys = append(keys, strings.ToUpper(q.Key))
+			}
+			content += fmt.Sprintf("Quantiles: %s\n", strings.Join(keys, ", "))
 		} else {
 			content = "No forecast data available"
 		}
 
 	case TabTables:
-		if m.snapshot != nil {
-			snap := m.snapshot.Snapshot
-			stepDuration := time.Duration(snap.StepSeconds) * time.Second
+		if snap := m.displaySnapshot(); snap != nil {
 			content = "Replica Scaling Data:\n\n"
-			content += "Time Offset\tForecast\tDesired Replicas\n"
-			for i := 0; i < len(snap.DesiredReplicas); i++ {
-				timeOffset := stepDuration * time.Duration(i)
-				forecastVal := 0.0
-				if i < len(snap.Values) {
-					forecastVal = snap.Values[i]
-				}
-				content += fmt.Sprintf("+%s\t%.2f\t%d\n",
-					timeOffset,
-					forecastVal,
-					snap.DesiredReplicas[i],
-				)
+			for _, record := range m.tableRecords(snap) {
+				content += strings.Join(record, "\t") + "\n"
 			}
 		} else {
 			content = "No table data available"
@@ -56,10 +60,11 @@ func (m *Model) copyCurrentTab() error {
 		var lines []string
 		lines = append(lines, "Workload Configuration:")
 		lines = append(lines, fmt.Sprintf("  Workload: %s", m.cfg.Workload))
-		if m.snapshot != nil {
-			lines = append(lines, fmt.Sprintf("  Metric: %s", m.snapshot.Snapshot.Metric))
-			lines = append(lines, fmt.Sprintf("  Step Duration: %ds", m.snapshot.Snapshot.StepSeconds))
-			lines = append(lines, fmt.Sprintf("  Horizon: %ds", m.snapshot.Snapshot.HorizonSeconds))
+		if m.quantileSnapshot != nil {
+			lines = append(lines, fmt.Sprintf("  Metric: %s", m.quantileSnapshot.Snapshot.Metric))
+			lines = append(lines, fmt.Sprintf("  Step Duration: %ds", m.quantileSnapshot.Snapshot.StepSeconds))
+			lines = append(lines, fmt.Sprintf("  Horizon: %ds", m.quantileSnapshot.Snapshot.HorizonSeconds))
+			lines = append(lines, fmt.Sprintf("  API Version: v%d", m.quantileSnapshot.APIVersion))
 		}
 		lines = append(lines, "")
 		lines = append(lines, "TUI Configuration:")
@@ -76,14 +81,19 @@ func (m *Model) copyCurrentTab() error {
 			content = "No logs available"
 		}
 
-	default:
-		return fmt.Errorf("unknown tab")
-	}
+	case TabAccuracy:
+		if m.accuracy != nil && len(m.accuracy.Buckets) > 0 {
+			content = "Forecast Accuracy by Lead Time:\n\n"
+			for _, record := range accuracyRecords(m.accuracy) {
+				content += strings.Join(record, "\t") + "\n"
+			}
+		} else {
+			content = "No accuracy data available"
+		}
 
-	if err := clipboard.WriteAll(content); err != nil {
-		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	default:
+		return "", fmt.Errorf("unknown tab")
 	}
 
-	m.toastManager.Add("✓ Copied to clipboard", components.ToastSuccess, 2*time.Second)
-	return nil
+	return content, nil
 }
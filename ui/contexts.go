@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/history"
+	"github.com/HatiCode/kedastral-tui/source"
+	"github.com/HatiCode/kedastral-tui/ui/panels"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m Model) openContextSwitcher() (Model, tea.Cmd) {
+	if m.cfg.Offline() {
+		// Contexts hold connection settings, which offline sources ignore
+		m.toastManager.Add("Contexts are not available offline", components.ToastWarning, 2*time.Second)
+		return m, nil
+	}
+
+	names := m.cfg.ContextNames()
+	if len(names) == 0 {
+		m.toastManager.Add("No contexts configured", components.ToastWarning, 2*time.Second)
+		return m, nil
+	}
+
+	m.showContexts = true
+	m.contextIndex = 0
+	for i, name := range names {
+		if name == m.cfg.CurrentContext {
+			m.contextIndex = i
+		}
+	}
+	return m, nil
+}
+
+func (m Model) handleContextSwitcher(msg tea.KeyMsg) (Model, tea.Cmd) {
+	names := m.cfg.ContextNames()
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "escape", "x", "q":
+		m.showContexts = false
+	case "j", "down":
+		if m.contextIndex < len(names)-1 {
+			m.contextIndex++
+		}
+	case "k", "up":
+		if m.contextIndex > 0 {
+			m.contextIndex--
+		}
+	case "enter":
+		m.showContexts = false
+		if m.contextIndex < len(names) {
+			return m.switchContext(names[m.contextIndex])
+		}
+	}
+
+	return m, nil
+}
+
+// switchContext points the model at another context, rebuilding the source
+// and dropping all data fetched from the previous one.
+func (m Model) switchContext(name string) (Model, tea.Cmd) {
+	if err := m.cfg.UseContext(name); err != nil {
+		m.toastManager.Add(fmt.Sprintf("Switch failed: %v", err), components.ToastError, 3*time.Second)
+		return m, nil
+	}
+
+	src, err := source.New(m.cfg)
+	if err != nil {
+		m.toastManager.Add(fmt.Sprintf("Context %s: %v", name, err), components.ToastError, 5*time.Second)
+	}
+	m.src = src
+	m.currentWorkload = m.cfg.Workload
+
+	m.resetWorkloadData()
+	m.forecasterHealthy = false
+	m.scalerHealthy = false
+	m.workloads = nil
+	m.sidebar = nil
+	m.err = nil
+	m.history = history.NewStore(m.cfg.HistorySize)
+	m.showOverview = false
+	m.overview = nil
+	m.overviewGen++
+	m.overviewIndex = 0
+	m.loading = true
+	m.sources = [sourceCount]sourceState{}
+	m.streaming = m.cfg.Stream && canStream(m.src)
+	fetch := m.refetch()
+
+	if err := config.SaveConfig(m.cfg); err != nil {
+		m.toastManager.Add(fmt.Sprintf("Context: %s (not saved: %v)", name, err), components.ToastWarning, 3*time.Second)
+	} else {
+		m.toastManager.Add(fmt.Sprintf("Context: %s", name), components.ToastSuccess, 2*time.Second)
+	}
+
+	return m, tea.Batch(
+		fetchWorkloadList(m.src),
+		fetch,
+		func() tea.Msg {
+			return panels.NewLogMsg{Log: fmt.Sprintf("Switched to context %s (%s)", name, m.cfg.ForecasterURL)}
+		},
+	)
+}
+
+func (m Model) renderContextSwitcher() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render("Switch Context"))
+	s.WriteString("\n\n")
+
+	for i, name := range m.cfg.ContextNames() {
+		ctx := m.cfg.Contexts[name]
+
+		prefix := "  "
+		if i == m.contextIndex {
+			prefix = "> "
+		}
+		current := ""
+		if name == m.cfg.CurrentContext {
+			current = " *"
+		}
+
+		label := fmt.Sprintf("%s%-16s", prefix, name+current)
+		if i == m.contextIndex {
+			label = selectedStyle.Render(label)
+		}
+		s.WriteString(label + " " + mutedStyle.Render(ctx.ForecasterURL))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(mutedStyle.Render("[↑/↓] select  [Enter] switch  [Esc] cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("39")).
+		Padding(1, 2).
+		Render(s.String())
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/HatiCode/kedastral-tui/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The chart cursor is the lead-time selection: moving it changes the lead
+// time used for every snapshot, so the replica table, overview and status
+// follow the step chosen on the chart. Enter starts cursor mode, where ←/→
+// move the cursor instead of switching tabs; clicks move it at any time.
+
+// chartCursorActive reports whether keys and clicks move the chart cursor.
+func (m Model) chartCursorActive() bool {
+	return m.activeTab == TabCharts && m.focusedPanel == PanelMain && !m.showHelp && !m.showOverview
+}
+
+func (m Model) handleChartCursor(msg tea.KeyMsg) (Model, tea.Cmd) {
+	snap := m.displaySnapshot()
+	if snap == nil {
+		m.cursorMode = false
+		return m, nil
+	}
+
+	i := snap.LeadTimeIndex
+	switch msg.String() {
+	case "left":
+		i--
+	case "right":
+		i++
+	case "home":
+		i = 0
+	case "end":
+		i = len(snap.Snapshot.DesiredReplicas) - 1
+	case "enter", "esc", "escape":
+		m.cursorMode = false
+		return m, nil
+	}
+	m.setLeadStep(snap, i)
+	return m, nil
+}
+
+// handleChartMouse moves the cursor to the step under a click or drag on the
+// chart.
+func (m Model) handleChartMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	if msg.Button != tea.MouseButtonLeft || (msg.Action != tea.MouseActionPress && msg.Action != tea.MouseActionMotion) {
+		return m, nil
+	}
+
+	snap := m.displaySnapshot()
+	if snap == nil {
+		return m, nil
+	}
+
+	// Tab content starts inside the main panel's border, below the status
+	// bar, the tab bar and a blank line
+	main := m.layoutMgr.Compute().Main
+	statusBar, tabBar, _ := m.mainChrome(main.W)
+	vp := m.tabViewport(TabCharts)
+	top := main.Y + 1 + lipgloss.Height(statusBar) + lipgloss.Height(tabBar) + 1
+	if msg.Y < top || msg.Y >= top+vp.Height {
+		return m, nil
+	}
+
+	x, y := msg.X-main.X-1, msg.Y-top+vp.YOffset
+	if i, ok := m.quantileChart(snap, main.W-4, chartHeight(main.H)).StepAt(snap, x, y); ok {
+		m.setLeadStep(snap, i)
+	}
+	return m, nil
+}
+
+// setLeadStep moves the lead time to step i of snap, clamped to its horizon.
+func (m *Model) setLeadStep(snap *client.QuantileSnapshotData, i int) {
+	n := len(snap.Snapshot.DesiredReplicas)
+	if n == 0 || snap.Snapshot.StepSeconds <= 0 {
+		return
+	}
+
+	i = min(max(i, 0), n-1)
+	m.cfg.LeadTime = time.Duration(i*snap.Snapshot.StepSeconds) * time.Second
+
+	// Keep the cursor inside the zoomed window
+	if m.chartSteps > 0 {
+		if i < m.chartStart {
+			m.chartStart = i
+		} else if i >= m.chartStart+m.chartSteps {
+			m.chartStart = i - m.chartSteps + 1
+		}
+	}
+}
+
+// withLeadTime returns a copy of snap whose LeadTimeIndex matches the current
+// lead time, leaving the stored snapshot untouched.
+func (m Model) withLeadTime(snap *client.QuantileSnapshotData) *client.QuantileSnapshotData {
+	if snap == nil {
+		return nil
+	}
+
+	cp := *snap
+	cp.LeadTimeIndex = client.LeadTimeIndex(m.cfg.LeadTime, snap.Snapshot.StepSeconds, len(snap.Snapshot.DesiredReplicas))
+	return &cp
+}
+
+// quantileChart creates the Charts tab chart for snap at the given size.
+func (m Model) quantileChart(snap *client.QuantileSnapshotData, width, height int) *components.QuantileChart {
+	chart := components.NewQuantileChart(width, height)
+	if m.cfg.ChartYLabels > 0 {
+		chart.SetYLabels(m.cfg.ChartYLabels)
+	}
+	chart.SetHighlight(m.cfg.HighlightQuantile)
+	chart.SetActuals(m.actualsFor(snap))
+	chart.SetCursor(snap.LeadTimeIndex)
+	chart.SetWindow(m.chartStart, m.chartSteps)
+	return chart
+}
+
+// cycleHighlight highlights the forecast's next quantile on the chart, after
+// the highest wrapping to the lowest, and saves the choice.
+func (m Model) cycleHighlight() (Model, tea.Cmd) {
+	snap := m.displaySnapshot()
+	if snap == nil || snap.APIVersion < 2 {
+		return m, nil
+	}
+
+	quantiles := snap.Snapshot.SortedQuantiles()
+	if len(quantiles) < 2 {
+		return m, nil
+	}
+
+	next := quantiles[(components.HighlightIndex(quantiles, m.cfg.HighlightQuantile)+1)%len(quantiles)]
+	m.cfg.HighlightQuantile = next.Key
+	if err := config.SaveConfig(m.cfg); err == nil {
+		m.toastManager.Add(fmt.Sprintf("Highlight: %s", strings.ToUpper(next.Key)), components.ToastInfo, 2*time.Second)
+	}
+	return m, nil
+}
+
+// replicaChart creates the desired-replicas chart drawn under the quantile
+// chart, sharing its cursor and window so the two stay aligned.
+func (m Model) replicaChart(snap *client.QuantileSnapshotData, width int) *components.ReplicaChart {
+	chart := components.NewReplicaChart(width, replicaChartHeight)
+	if m.cfg.ChartYLabels > 0 {
+		chart.SetYLabels(m.cfg.ChartYLabels)
+	}
+	chart.SetCursor(snap.LeadTimeIndex)
+	chart.SetCurrent(m.currentReplicas())
+	chart.SetWindow(m.chartStart, m.chartSteps)
+	return chart
+}
+
+// currentReplicas returns the selected workload's current replica count, or
+// zero when the workload list doesn't report it.
+func (m Model) currentReplicas() int {
+	for _, w := range m.workloads {
+		if w.Name == m.currentWorkload {
+			return w.CurrentReplicas
+		}
+	}
+	return 0
+}
+
+// replicaChartHeight is the height of the desired-replicas chart.
+const replicaChartHeight = 8
+
+// chartHeight returns the Charts tab chart height for a main panel height.
+func chartHeight(height int) int {
+	if height > 30 {
+		return 12
+	}
+	return 10
+}
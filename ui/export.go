@@ -4,15 +4,20 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/HatiCode/kedastral-tui/accuracy"
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/HatiCode/kedastral-tui/components"
 )
 
 func (m *Model) exportCurrentTab() error {
-	timestamp := time.Now().Format("20060102-150405")
+	timestamp := clock.Now().Format("20060102-150405")
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -28,16 +33,9 @@ func (m *Model) exportCurrentTab() error {
 
 	switch m.activeTab {
 	case TabCharts:
-		if m.quantileSnapshot != nil {
-			filename = filepath.Join(downloadsDir, fmt.Sprintf("kedastral-forecast-%s.json", timestamp))
-			data, err := json.MarshalIndent(m.quantileSnapshot, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal forecast data: %w", err)
-			}
-			exportErr = os.WriteFile(filename, data, 0644)
-		} else if m.snapshot != nil {
+		if snap := m.displaySnapshot(); snap != nil {
 			filename = filepath.Join(downloadsDir, fmt.Sprintf("kedastral-forecast-%s.json", timestamp))
-			data, err := json.MarshalIndent(m.snapshot, "", "  ")
+			data, err := json.MarshalIndent(snap, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal forecast data: %w", err)
 			}
@@ -47,7 +45,8 @@ func (m *Model) exportCurrentTab() error {
 		}
 
 	case TabTables:
-		if m.snapshot == nil {
+		snap := m.displaySnapshot()
+		if snap == nil {
 			return fmt.Errorf("no table data to export")
 		}
 
@@ -59,27 +58,16 @@ func (m *Model) exportCurrentTab() error {
 		defer file.Close()
 
 		writer := csv.NewWriter(file)
-		defer writer.Flush()
 
-		writer.Write([]string{"Time Offset (seconds)", "Forecast Value", "Desired Replicas"})
-
-		snap := m.snapshot.Snapshot
-		stepDuration := time.Duration(snap.StepSeconds) * time.Second
-		for i := 0; i < len(snap.DesiredReplicas); i++ {
-			timeOffset := stepDuration * time.Duration(i)
-			forecastVal := 0.0
-			if i < len(snap.Values) {
-				forecastVal = snap.Values[i]
-			}
-			record := []string{
-				fmt.Sprintf("%.0f", timeOffset.Seconds()),
-				fmt.Sprintf("%.2f", forecastVal),
-				fmt.Sprintf("%d", snap.DesiredReplicas[i]),
-			}
+		for _, record := range m.tableRecords(snap) {
 			if err := writer.Write(record); err != nil {
 				return fmt.Errorf("failed to write CSV record: %w", err)
 			}
 		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV file: %w", err)
+		}
 
 	case TabConfig:
 		filename = filepath.Join(downloadsDir, fmt.Sprintf("kedastral-config-%s.json", timestamp))
@@ -91,10 +79,11 @@ func (m *Model) exportCurrentTab() error {
 			"lead_time":        m.cfg.LeadTime.String(),
 		}
 
-		if m.snapshot != nil {
-			configData["metric"] = m.snapshot.Snapshot.Metric
-			configData["step_seconds"] = m.snapshot.Snapshot.StepSeconds
-			configData["horizon_seconds"] = m.snapshot.Snapshot.HorizonSeconds
+		if m.quantileSnapshot != nil {
+			configData["metric"] = m.quantileSnapshot.Snapshot.Metric
+			configData["step_seconds"] = m.quantileSnapshot.Snapshot.StepSeconds
+			configData["horizon_seconds"] = m.quantileSnapshot.Snapshot.HorizonSeconds
+			configData["api_version"] = m.quantileSnapshot.APIVersion
 		}
 
 		data, err := json.MarshalIndent(configData, "", "  ")
@@ -110,6 +99,30 @@ func (m *Model) exportCurrentTab() error {
 		logsContent += "(Log content from bottom panel would go here)\n"
 		exportErr = os.WriteFile(filename, []byte(logsContent), 0644)
 
+	case TabAccuracy:
+		if m.accuracy == nil || len(m.accuracy.Buckets) == 0 {
+			return fmt.Errorf("no accuracy data to export")
+		}
+
+		filename = filepath.Join(downloadsDir, fmt.Sprintf("kedastral-accuracy-%s.csv", timestamp))
+		file, err := os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		defer file.Close()
+
+		writer := csv.NewWriter(file)
+
+		for _, record := range accuracyRecords(m.accuracy) {
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write CSV record: %w", err)
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return fmt.Errorf("failed to write CSV file: %w", err)
+		}
+
 	default:
 		return fmt.Errorf("cannot export from this tab")
 	}
@@ -121,3 +134,100 @@ func (m *Model) exportCurrentTab() error {
 	m.toastManager.Add(fmt.Sprintf("✓ Exported to %s", filepath.Base(filename)), components.ToastSuccess, 3*time.Second)
 	return nil
 }
+
+// accuracyRecords returns report as a header and one record per lead-time
+// bucket. Scores a bucket has no data for are left blank.
+func accuracyRecords(report *accuracy.Report) [][]string {
+	header := []string{"Lead From (seconds)", "Lead To (seconds)", "Samples", "MAPE P50", "P10-P90 Coverage"}
+	for _, q := range report.Quantiles {
+		header = append(header, fmt.Sprintf("Pinball %s", q))
+	}
+
+	score := func(v float64, ok bool) string {
+		if !ok || math.IsNaN(v) {
+			return ""
+		}
+		return fmt.Sprintf("%.4f", v)
+	}
+
+	records := [][]string{header}
+	for _, bucket := range report.Buckets {
+		record := []string{
+			fmt.Sprintf("%.0f", bucket.From.Seconds()),
+			fmt.Sprintf("%.0f", bucket.To.Seconds()),
+			fmt.Sprintf("%d", bucket.Samples),
+			score(bucket.MAPE, true),
+			score(bucket.Coverage, true),
+		}
+		for _, q := range report.Quantiles {
+			loss, ok := bucket.Pinball[q]
+			record = append(record, score(loss, ok))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// tableRecords returns the Tables tab data for snap as a header and one
+// record per step, in forecast order with every column the tab can show.
+func (m Model) tableRecords(snap *client.QuantileSnapshotData) [][]string {
+	s := snap.Snapshot
+	actuals := m.actualsFor(snap)
+
+	var quantiles []client.Quantile
+	if snap.APIVersion >= 2 {
+		quantiles = s.SortedQuantiles()
+	}
+
+	header := []string{"Time", "Time Offset (seconds)"}
+	if len(quantiles) > 0 {
+		for _, q := range quantiles {
+			header = append(header, strings.ToUpper(q.Key))
+		}
+	} else {
+		header = append(header, "Forecast Value")
+	}
+	if actuals != nil {
+		header = append(header, "Actual", "Error")
+	}
+	header = append(header, "Desired Replicas", "Replica Change")
+
+	// Steps past the end of a series are left blank
+	value := func(values []float64, i int) string {
+		if i < len(values) && !math.IsNaN(values[i]) {
+			return fmt.Sprintf("%.2f", values[i])
+		}
+		return ""
+	}
+
+	records := [][]string{header}
+	for i, replicas := range s.DesiredReplicas {
+		offset := time.Duration(i*s.StepSeconds) * time.Second
+		record := []string{
+			s.GeneratedAt.Add(offset).Format(time.RFC3339),
+			fmt.Sprintf("%.0f", offset.Seconds()),
+		}
+		if len(quantiles) > 0 {
+			for _, q := range quantiles {
+				record = append(record, value(q.Values, i))
+			}
+		} else {
+			record = append(record, value(s.Values, i))
+		}
+		if actuals != nil {
+			forecastErr := ""
+			if i < len(actuals) && i < len(s.Values) && !math.IsNaN(actuals[i]) && !math.IsNaN(s.Values[i]) {
+				forecastErr = fmt.Sprintf("%.2f", actuals[i]-s.Values[i])
+			}
+			record = append(record, value(actuals, i), forecastErr)
+		}
+
+		change := 0
+		if i > 0 {
+			change = replicas - s.DesiredReplicas[i-1]
+		}
+		record = append(record, fmt.Sprintf("%d", replicas), fmt.Sprintf("%d", change))
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/source"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sourceID identifies a data source fetched on every refresh.
+type sourceID int
+
+const (
+	sourceForecast sourceID = iota
+	sourceScalerMetrics
+	sourceForecasterHealth
+	sourceScalerHealth
+	sourceCount
+)
+
+// Per-source request timeouts. Health checks are cheap and should fail fast.
+var sourceTimeouts = [sourceCount]time.Duration{
+	sourceForecast:         5 * time.Second,
+	sourceScalerMetrics:    5 * time.Second,
+	sourceForecasterHealth: 2 * time.Second,
+	sourceScalerHealth:     2 * time.Second,
+}
+
+func (s sourceID) String() string {
+	switch s {
+	case sourceForecast:
+		return "Forecast"
+	case sourceScalerMetrics:
+		return "Scaler metrics"
+	case sourceForecasterHealth:
+		return "Forecaster health"
+	case sourceScalerHealth:
+		return "Scaler health"
+	default:
+		return "Unknown"
+	}
+}
+
+// sourceState tracks the outcome of the latest request to a source.
+type sourceState struct {
+	lastSuccess time.Time
+	lastAttempt time.Time
+	err         error
+}
+
+// record stores the outcome of a request and reports whether the source
+// started failing or recovered, so callers log transitions rather than
+// every failed refresh.
+func (s *sourceState) record(err error, at time.Time) bool {
+	changed := (err != nil) != (s.err != nil) || (s.lastAttempt.IsZero() && err != nil)
+	s.lastAttempt = at
+	s.err = err
+	if err == nil {
+		s.lastSuccess = at
+	}
+	return changed
+}
+
+// restartFetch cancels every in-flight request for the current generation and
+// starts a new one, so responses to earlier requests are dropped on arrival.
+func (m *Model) restartFetch() {
+	if m.cancelFetch != nil {
+		m.cancelFetch()
+	}
+	m.fetchCtx, m.cancelFetch = context.WithCancel(context.Background())
+	m.fetchGen++
+	m.streamLive = false
+}
+
+// refetch restarts fetching for the current workload, reopening the forecast
+// stream when streaming.
+func (m *Model) refetch() tea.Cmd {
+	m.restartFetch()
+	return tea.Batch(m.fetchCurrent(), m.openStream())
+}
+
+// fetchCurrent fetches data for the current workload in the current
+// generation.
+func (m Model) fetchCurrent() tea.Cmd {
+	pollSnapshot := !m.streaming || !m.streamLive
+	return fetchData(m.fetchCtx, m.src, m.currentWorkload, m.cfg.LeadTime, m.fetchGen, pollSnapshot)
+}
+
+// current reports whether a response for workload in generation gen still
+// applies to the model.
+func (m Model) current(workload string, gen uint64) bool {
+	return workload == m.currentWorkload && gen == m.fetchGen
+}
+
+// fetchData fetches every source concurrently. Each source is its own command
+// with its own timeout, so a slow scaler does not hold back the forecast.
+// The snapshot is skipped unless pollSnapshot is set, e.g. while it arrives
+// over a stream.
+func fetchData(parent context.Context, src source.ForecastSource, workload string, leadTime time.Duration, gen uint64, pollSnapshot bool) tea.Cmd {
+	cmds := []tea.Cmd{
+		fetchScalerMetrics(parent, src, workload, gen),
+		fetchHealth(parent, sourceForecasterHealth, src.CheckForecasterHealth, gen),
+		fetchHealth(parent, sourceScalerHealth, src.CheckScalerHealth, gen),
+	}
+	if pollSnapshot {
+		cmds = append(cmds, fetchSnapshot(parent, src, workload, leadTime, gen))
+	}
+	return tea.Batch(cmds...)
+}
+
+func fetchSnapshot(parent context.Context, src source.ForecastSource, workload string, leadTime time.Duration, gen uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, sourceTimeouts[sourceForecast])
+		defer cancel()
+
+		data, err := src.GetQuantileSnapshot(ctx, workload, leadTime)
+		return quantileSnapshotMsg{workload: workload, gen: gen, data: data, err: err}
+	}
+}
+
+func fetchScalerMetrics(parent context.Context, src source.ForecastSource, workload string, gen uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, sourceTimeouts[sourceScalerMetrics])
+		defer cancel()
+
+		data, err := src.GetScalerMetrics(ctx, workload)
+		return scalerMetricsMsg{workload: workload, gen: gen, data: data, err: err}
+	}
+}
+
+func fetchHealth(parent context.Context, source sourceID, check func(context.Context) error, gen uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, sourceTimeouts[source])
+		defer cancel()
+
+		return healthMsg{source: source, gen: gen, err: check(ctx)}
+	}
+}
+
+func fetchObserved(parent context.Context, src source.ObservedSource, workload, metric string, start, end time.Time, step time.Duration, gen uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(parent, 5*time.Second)
+		defer cancel()
+
+		data, err := src.GetObservedValues(ctx, workload, metric, start, end, step)
+		return observedMsg{workload: workload, gen: gen, data: data, err: err}
+	}
+}
+
+// resetWorkloadData clears everything derived from the current workload's
+// data.
+func (m *Model) resetWorkloadData() {
+	m.quantileSnapshot = nil
+	m.scalerMetrics = nil
+	m.scrubbing = false
+	m.chartStart, m.chartSteps = 0, 0
+	m.table.cursor = -1
+	m.observed = nil
+	m.observedFetchedAt = time.Time{}
+	m.accuracy = nil
+	if m.bottomPanel != nil {
+		m.bottomPanel.ResetMetrics()
+	}
+}
@@ -27,13 +27,26 @@ func (m Model) handleFocusSwitch(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "[":
 		// Toggle sidebar collapse
 		m.layoutMgr.ToggleSidebar()
+		m.resizePanels()
 		return m, nil
 
 	case "]":
 		// Toggle bottom panel collapse
 		m.layoutMgr.ToggleBottom()
+		m.resizePanels()
 		return m, nil
 	}
 
 	return m, nil
 }
+
+// resizePanels fits the sidebar and bottom panel to the current layout.
+func (m *Model) resizePanels() {
+	layout := m.layoutMgr.Compute()
+	if m.sidebar != nil {
+		m.sidebar.SetSize(layout.Sidebar.W, layout.Sidebar.H)
+	}
+	if m.bottomPanel != nil {
+		m.bottomPanel.SetSize(layout.Bottom.W, layout.Bottom.H)
+	}
+}
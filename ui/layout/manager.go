@@ -7,19 +7,20 @@ type Rect struct {
 	W, H int // Width and Height
 }
 
+// border is the rows or columns a panel's rounded border takes up.
+const border = 2
+
 // LayoutManager computes panel dimensions based on terminal size.
 type LayoutManager struct {
 	termWidth  int
 	termHeight int
 
-	sidebarWidth int // Computed: 25% of width, min 20, max 40
-	bottomHeight int // Computed: 20% of height, min 5, max 15
-
 	sidebarCollapsed bool
 	bottomCollapsed  bool
 }
 
-// Layout holds the computed dimensions for all panels.
+// Layout holds the computed dimensions for all panels. Each rect is a
+// panel's outer box, border included, and together they fill the terminal.
 type Layout struct {
 	Sidebar Rect
 	Main    Rect
@@ -54,40 +55,21 @@ func (l *LayoutManager) ToggleBottom() {
 func (l *LayoutManager) Compute() Layout {
 	layout := Layout{}
 
-	// Sidebar: 25% of width, min 20 cols, max 40 cols
-	sidebarWidth := clamp(l.termWidth/4, 20, 40)
+	// Sidebar: 25% of width, min 20 cols, max 40 cols, plus its border
+	sidebarWidth := clamp(l.termWidth/4, 20, 40) + border
 	if l.sidebarCollapsed {
 		sidebarWidth = 0
 	}
 
-	// Bottom: 20% of height, min 5 rows, max 15 rows
-	bottomHeight := clamp(l.termHeight/5, 5, 15)
+	// Bottom: 20% of height, min 5 rows, max 15 rows, plus its border
+	bottomHeight := clamp(l.termHeight/5, 5, 15) + border
 	if l.bottomCollapsed {
 		bottomHeight = 0
 	}
 
 	// Main: remaining space
-	// Reserve 2 columns for borders between panels (1 on each side)
-	borderWidth := 0
-	if sidebarWidth > 0 {
-		borderWidth = 2
-	}
-
-	mainWidth := l.termWidth - sidebarWidth - borderWidth
-	if mainWidth < 0 {
-		mainWidth = 0
-	}
-
-	// Reserve 2 rows for borders between main and bottom
-	borderHeight := 0
-	if bottomHeight > 0 {
-		borderHeight = 2
-	}
-
-	mainHeight := l.termHeight - bottomHeight - borderHeight
-	if mainHeight < 0 {
-		mainHeight = 0
-	}
+	mainWidth := max(l.termWidth-sidebarWidth, 0)
+	mainHeight := max(l.termHeight-bottomHeight, 0)
 
 	// Sidebar: left side, full height
 	layout.Sidebar = Rect{
@@ -99,7 +81,7 @@ func (l *LayoutManager) Compute() Layout {
 
 	// Main: right of sidebar, above bottom
 	layout.Main = Rect{
-		X: sidebarWidth + borderWidth,
+		X: sidebarWidth,
 		Y: 0,
 		W: mainWidth,
 		H: mainHeight,
@@ -107,8 +89,8 @@ func (l *LayoutManager) Compute() Layout {
 
 	// Bottom: right of sidebar, below main
 	layout.Bottom = Rect{
-		X: sidebarWidth + borderWidth,
-		Y: mainHeight + borderHeight,
+		X: sidebarWidth,
+		Y: mainHeight,
 		W: mainWidth,
 		H: bottomHeight,
 	}
@@ -9,19 +9,17 @@ import (
 
 type tickMsg time.Time
 
-type snapshotMsg struct {
-	data *client.SnapshotData
-	err  error
-}
-
 type scalerMetricsMsg struct {
-	data *client.ScalerMetrics
-	err  error
+	workload string
+	gen      uint64
+	data     *client.ScalerMetrics
+	err      error
 }
 
 type healthMsg struct {
-	forecasterHealthy bool
-	scalerHealthy     bool
+	source sourceID
+	gen    uint64
+	err    error
 }
 
 type workloadListMsg struct {
@@ -30,6 +28,20 @@ type workloadListMsg struct {
 }
 
 type quantileSnapshotMsg struct {
-	data *client.QuantileSnapshotData
-	err  error
+	workload string
+	gen      uint64
+	data     *client.QuantileSnapshotData
+	err      error
+}
+
+type overviewMsg struct {
+	gen     uint64
+	entries map[string]overviewEntry
+}
+
+type observedMsg struct {
+	workload string
+	gen      uint64
+	data     *client.ObservedSeries
+	err      error
 }
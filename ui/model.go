@@ -5,9 +5,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/HatiCode/kedastral-tui/accuracy"
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/HatiCode/kedastral-tui/components"
 	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/history"
+	"github.com/HatiCode/kedastral-tui/session"
+	"github.com/HatiCode/kedastral-tui/source"
 	"github.com/HatiCode/kedastral-tui/ui/layout"
 	"github.com/HatiCode/kedastral-tui/ui/panels"
 	"github.com/HatiCode/kedastral-tui/ui/theme"
@@ -39,13 +44,13 @@ const (
 	TabTables
 	TabConfig
 	TabLogs
+	TabAccuracy
 )
 
 type Model struct {
 	cfg               *config.Config
-	client            *client.Client
+	src               source.ForecastSource
 	mode              Mode
-	snapshot          *client.SnapshotData
 	quantileSnapshot  *client.QuantileSnapshotData
 	scalerMetrics     *client.ScalerMetrics
 	forecasterHealthy bool
@@ -56,6 +61,7 @@ type Model struct {
 	height            int
 	ready             bool
 	showHelp          bool
+	helpOffset        int
 	loading           bool
 	spinner           components.LoadingSpinner
 	toastManager      *components.ToastManager
@@ -78,9 +84,53 @@ type Model struct {
 
 	tabViewports map[TabID]viewport.Model
 	theme        *theme.Theme
+
+	history    *history.Store
+	scrubbing  bool
+	scrubIndex int
+
+	// Set while ←/→ move the chart cursor instead of switching tabs
+	cursorMode bool
+
+	// Steps shown on the Charts tab when zoomed; chartSteps of 0 shows the
+	// whole horizon
+	chartStart int
+	chartSteps int
+
+	table tableState
+
+	observed          *client.ObservedSeries
+	observedFetchedAt time.Time
+	accuracy          *accuracy.Report
+
+	showContexts bool
+	contextIndex int
+
+	showOverview  bool
+	overviewIndex int
+	overview      map[string]overviewEntry
+	overviewGen   uint64
+
+	// Requests belong to a generation; switching workload or refreshing
+	// cancels the current one and drops its late responses
+	fetchGen    uint64
+	fetchCtx    context.Context
+	cancelFetch context.CancelFunc
+
+	sources [sourceCount]sourceState
+
+	// streaming is set while forecasts arrive over the forecaster's event
+	// stream; streamLive once the current stream has delivered a snapshot.
+	// The latest snapshot streamed while paused is held until resuming
+	streaming    bool
+	streamLive   bool
+	streamedHeld *quantileSnapshotMsg
+
+	recorder *session.Recorder
+	replay   *replayState
 }
 
-func NewModel(cfg *config.Config, c *client.Client) Model {
+func NewModel(cfg *config.Config, src source.ForecastSource) Model {
 	tabBar := panels.NewTabBar(100)
 	bottomPanel := panels.NewBottomPanel(100, 10, cfg)
 	spinner := components.NewLoadingSpinner()
@@ -88,14 +138,16 @@ func NewModel(cfg *config.Config, c *client.Client) Model {
 	currentTheme := theme.Get(cfg.Theme)
 
 	tabViewports := make(map[TabID]viewport.Model)
-	for _, tabID := range []TabID{TabCharts, TabTables, TabConfig, TabLogs} {
+	for _, tabID := range []TabID{TabCharts, TabTables, TabConfig, TabLogs, TabAccuracy} {
 		vp := viewport.New(100, 20)
 		tabViewports[tabID] = vp
 	}
 
+	fetchCtx, cancelFetch := context.WithCancel(context.Background())
+
 	return Model{
 		cfg:             cfg,
-		client:          c,
+		src:             src,
 		mode:            ModeLive,
 		focusedPanel:    PanelMain,
 		layoutMgr:       layout.NewLayoutManager(),
@@ -108,30 +160,47 @@ func NewModel(cfg *config.Config, c *client.Client) Model {
 		loading:         false,
 		tabViewports:    tabViewports,
 		theme:           currentTheme,
+		history:         history.NewStore(cfg.HistorySize),
+		fetchCtx:        fetchCtx,
+		cancelFetch:     cancelFetch,
+		streaming:       cfg.Stream && canStream(src),
+		table:           tableState{cursor: -1},
 	}
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.replay != nil {
+		return tea.Batch(m.spinner.Init(), replayTick())
+	}
+
 	return tea.Batch(
 		m.spinner.Init(),
 		tick(m.cfg.RefreshInterval),
-		fetchWorkloadList(m.client),
-		fetchData(m.client, m.cfg.Workload, m.cfg.LeadTime),
+		fetchWorkloadList(m.src),
+		m.fetchCurrent(),
+		m.openStream(),
 	)
 }
 
-func fetchWorkloadList(c *client.Client) tea.Cmd {
+func fetchWorkloadList(src source.ForecastSource) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		workloads, err := c.GetWorkloads(ctx)
+		workloads, err := src.GetWorkloads(ctx)
 		return workloadListMsg{workloads: workloads, err: err}
 	}
 }
 
 func tick(d time.Duration) tea.Cmd {
-	return tea.Tick(d, func(t time.Time) tea.Msg {
+	return after(d, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
+
+// after sends fn's message once d has passed on the clock, like tea.Tick.
+func after(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return fn(<-clock.After(d))
+	}
+}
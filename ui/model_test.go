@@ -0,0 +1,640 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/accuracy"
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		Workload:        "checkout",
+		RefreshInterval: time.Hour, // Refresh ticks never fire during a test
+		LeadTime:        5 * time.Minute,
+		Theme:           "dark",
+		HistorySize:     10,
+		ReplaySpeed:     1,
+	}
+}
+
+// newDriver starts a model against src at the given size, with its first
+// fetch completed.
+func newDriver(t *testing.T, src *uitest.Source, width, height int) *uitest.Driver {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	uitest.PinClock(t)
+
+	return drive(t, NewModel(testConfig(), src)).Resize(width, height).Init()
+}
+
+// drive returns a driver for model that drops refresh and replay ticks. On
+// the pinned clock they fire at once and would reschedule themselves forever.
+func drive(t *testing.T, model Model) *uitest.Driver {
+	t.Helper()
+	return uitest.New(t, model).Ignore(func(msg tea.Msg) bool {
+		switch msg.(type) {
+		case tickMsg, replayTickMsg:
+			return true
+		}
+		return false
+	})
+}
+
+var modelSizes = []struct{ width, height int }{{80, 24}, {120, 40}, {160, 50}}
+
+func TestModelTabsGolden(t *testing.T) {
+	tabs := []struct {
+		name string
+		keys []string
+	}{
+		{name: "charts"},
+		{name: "tables", keys: []string{"2"}},
+		{name: "config", keys: []string{"3"}},
+		{name: "accuracy", keys: []string{"5"}},
+		{name: "help", keys: []string{"h"}},
+	}
+
+	for _, tab := range tabs {
+		name := "model_" + tab.name
+		t.Run(name, func(t *testing.T) {
+			newDriver(t, uitest.NewSource(), 120, 40).
+				Keys(tab.keys...).
+				Golden(name)
+		})
+	}
+}
+
+func TestModelViewFits(t *testing.T) {
+	screens := []struct {
+		name string
+		keys []string
+	}{
+		{name: "charts"},
+		{name: "tables", keys: []string{"2"}},
+		{name: "config", keys: []string{"3"}},
+		{name: "accuracy", keys: []string{"5"}},
+		{name: "help", keys: []string{"h"}},
+		{name: "help scrolled", keys: []string{"h", "G"}},
+		{name: "no sidebar", keys: []string{"["}},
+		{name: "no bottom panel", keys: []string{"]"}},
+	}
+
+	for _, size := range modelSizes {
+		for _, screen := range screens {
+			t.Run(fmt.Sprintf("%s %dx%d", screen.name, size.width, size.height), func(t *testing.T) {
+				view := newDriver(t, uitest.NewSource(), size.width, size.height).
+					Keys(screen.keys...).
+					Model().View()
+				lines := strings.Split(view, "\n")
+				if len(lines) != size.height {
+					t.Errorf("view has %d lines, want %d", len(lines), size.height)
+				}
+				for i, line := range lines {
+					if w := lipgloss.Width(line); w > size.width {
+						t.Errorf("line %d is %d columns wide, want at most %d", i+1, w, size.width)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestModelHelpScroll(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 80, 24).Keys("h", "j", "j")
+	if m := d.Model().(Model); m.helpOffset != 2 {
+		t.Fatalf("helpOffset = %d after jj, want 2", m.helpOffset)
+	}
+	d.Keys("k")
+	if m := d.Model().(Model); m.helpOffset != 1 {
+		t.Fatalf("helpOffset = %d after k, want 1", m.helpOffset)
+	}
+	d.Keys("G")
+	m := d.Model().(Model)
+	if max := m.helpViewport().TotalLineCount() - (m.height - 1); m.helpOffset != max {
+		t.Errorf("helpOffset = %d after G, want %d", m.helpOffset, max)
+	}
+	if m = d.Keys("h").Model().(Model); m.showHelp {
+		t.Error("help still open after h")
+	}
+}
+
+func TestModelFocusGolden(t *testing.T) {
+	// Move focus to the sidebar and pick the second workload
+	newDriver(t, uitest.NewSource(), 120, 40).
+		Keys("shift+tab", "down", "enter").
+		Golden("model_sidebar")
+}
+
+func TestModelOutageGolden(t *testing.T) {
+	src := uitest.NewSource()
+	src.Stale = true
+	src.ScalerErr = errors.New("failed to fetch metrics: connection refused")
+
+	newDriver(t, src, 120, 40).Golden("model_outage")
+}
+
+func TestModelPause(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys(" ")
+	if m := d.Model().(Model); m.mode != ModePaused {
+		t.Fatalf("mode = %v after space, want paused", m.mode)
+	}
+	d.Keys(" ")
+	if m := d.Model().(Model); m.mode != ModeLive {
+		t.Fatalf("mode = %v after second space, want live", m.mode)
+	}
+}
+
+func TestModelScrub(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40)
+
+	// sendSnapshot delivers checkout's forecast generated i minutes after the
+	// first one
+	sendSnapshot := func(i int) {
+		snap := uitest.Snapshot("checkout")
+		snap.GeneratedAt = snap.GeneratedAt.Add(time.Duration(i) * time.Minute)
+		d.Send(quantileSnapshotMsg{workload: "checkout", gen: d.Model().(Model).fetchGen, data: client.NewQuantileSnapshotData(snap, false, 5*time.Minute)})
+	}
+	generated := func(m Model) time.Duration {
+		return m.displaySnapshot().Snapshot.GeneratedAt.Sub(uitest.Snapshot("checkout").GeneratedAt)
+	}
+
+	for i := 1; i <= 3; i++ {
+		sendSnapshot(i)
+	}
+	d.Keys("s", "left", "left")
+	m := d.Model().(Model)
+	if m.history.Len("checkout") != 4 || m.scrubIndex != 1 || generated(m) != time.Minute {
+		t.Fatalf("history = %d, index = %d, showing +%v; want 4 snapshots, index 1, +1m", m.history.Len("checkout"), m.scrubIndex, generated(m))
+	}
+
+	// New snapshots evicting the oldest keep the scrubbed one in view
+	for i := 4; i <= 10; i++ {
+		sendSnapshot(i)
+	}
+	if m := d.Model().(Model); m.history.Len("checkout") != 10 || m.scrubIndex != 0 || generated(m) != time.Minute {
+		t.Fatalf("history = %d, index = %d, showing +%v after evictions; want 10, 0, +1m", m.history.Len("checkout"), m.scrubIndex, generated(m))
+	}
+
+	d.Keys("end")
+	if m := d.Model().(Model); generated(m) != 10*time.Minute {
+		t.Fatalf("showing +%v after end, want the latest +10m", generated(m))
+	}
+	d.Keys("esc")
+	if m := d.Model().(Model); m.scrubbing {
+		t.Fatalf("still scrubbing after esc")
+	}
+}
+
+func TestModelOverviewDropsStaleFetch(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys("o")
+	m := d.Model().(Model)
+	if !m.showOverview || len(m.overview) == 0 {
+		t.Fatalf("overview shown = %v with %d entries, want it loaded", m.showOverview, len(m.overview))
+	}
+
+	stale := map[string]overviewEntry{"checkout": {err: errors.New("stale")}}
+	d.Send(overviewMsg{gen: m.overviewGen - 1, entries: stale})
+	if got := d.Model().(Model).overview["checkout"].err; got != nil {
+		t.Fatalf("stale overview applied: %v", got)
+	}
+
+	d.Send(overviewMsg{gen: m.overviewGen, entries: stale})
+	if got := d.Model().(Model).overview["checkout"].err; got == nil {
+		t.Fatalf("current overview dropped")
+	}
+}
+
+func TestModelScrubNamespacedWorkload(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40)
+
+	// The forecaster names the workload differently from the request, and
+	// reports its time in another zone
+	snap := uitest.Snapshot("shop/checkout")
+	snap.GeneratedAt = snap.GeneratedAt.Add(time.Minute).In(time.FixedZone("CET", 3600))
+	d.Send(quantileSnapshotMsg{workload: "checkout", gen: d.Model().(Model).fetchGen, data: client.NewQuantileSnapshotData(snap, false, 5*time.Minute)})
+
+	m := d.Keys("s").Model().(Model)
+	if !m.scrubbing || m.history.Len("checkout") != 2 {
+		t.Fatalf("scrubbing = %v with %d snapshots for checkout, want both snapshots kept under the requested workload", m.scrubbing, m.history.Len("checkout"))
+	}
+	if got, want := m.scrubStatus(), "viewing snapshot 2 of 2 (generated "+clock.Local(snap.GeneratedAt).Format("15:04:05")+")"; got != want {
+		t.Errorf("scrubStatus() = %q, want %q", got, want)
+	}
+}
+
+func TestClipboardTextScrubbed(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40)
+	older := d.Model().(Model).quantileSnapshot.Snapshot.GeneratedAt
+
+	snap := uitest.Snapshot("checkout")
+	snap.GeneratedAt = snap.GeneratedAt.Add(time.Minute)
+	d.Send(quantileSnapshotMsg{workload: "checkout", gen: d.Model().(Model).fetchGen, data: client.NewQuantileSnapshotData(snap, false, 5*time.Minute)})
+
+	// The Charts tab copies the snapshot being viewed, not the latest one
+	m := d.Keys("s", "left").Model().(Model)
+	got, err := m.clipboardText()
+	if err != nil {
+		t.Fatalf("clipboardText: %v", err)
+	}
+	if want := "Generated At: " + older.Format(time.RFC3339); !strings.Contains(got, want) {
+		t.Errorf("clipboardText = %q, want %q", got, want)
+	}
+}
+
+func TestModelChartCursor(t *testing.T) {
+	// Without cursor mode, → switches tabs
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys("right")
+	if m := d.Model().(Model); m.activeTab != TabTables || m.cfg.LeadTime != 5*time.Minute {
+		t.Fatalf("tab = %v, lead time = %v after right on Charts, want the Tables tab and 5m", m.activeTab, m.cfg.LeadTime)
+	}
+
+	d.Keys("1", "enter", "right", "right", "right")
+	if m := d.Model().(Model); !m.cursorMode || m.activeTab != TabCharts || m.cfg.LeadTime != 8*time.Minute {
+		t.Fatalf("cursor mode = %v, tab = %v, lead time = %v after moving the cursor right 3 times, want cursor mode on Charts at 8m",
+			m.cursorMode, m.activeTab, m.cfg.LeadTime)
+	}
+	d.Golden("model_cursor")
+
+	d.Keys("end")
+	if m := d.Model().(Model); m.cfg.LeadTime != 29*time.Minute {
+		t.Fatalf("lead time = %v after end, want 29m", m.cfg.LeadTime)
+	}
+	d.Keys("esc", "right")
+	if m := d.Model().(Model); m.cursorMode || m.activeTab == TabCharts || m.cfg.LeadTime != 29*time.Minute {
+		t.Fatalf("cursor mode = %v, tab = %v, lead time = %v after esc and right, want the cursor left at 29m and the tab switched",
+			m.cursorMode, m.activeTab, m.cfg.LeadTime)
+	}
+}
+
+func TestModelChartMouse(t *testing.T) {
+	// The sidebar is 32 columns wide and the y-axis takes 7 more. The plot
+	// takes rows 9 to 14, under the chart title and above the time axis.
+	click := func(x, y int) tea.MouseMsg {
+		return tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+	}
+
+	tests := []struct {
+		name string
+		msg  tea.MouseMsg
+		want time.Duration
+	}{
+		{name: "first column", msg: click(40, 12), want: 0},
+		{name: "last row", msg: click(40, 14), want: 0},
+		{name: "title", msg: click(40, 7), want: 5 * time.Minute},
+		{name: "time axis", msg: click(40, 15), want: 5 * time.Minute},
+		{name: "replica chart", msg: click(40, 23), want: 5 * time.Minute},
+		{name: "y-axis", msg: click(38, 12), want: 5 * time.Minute},
+		{name: "right button", msg: tea.MouseMsg{X: 40, Y: 12, Button: tea.MouseButtonRight, Action: tea.MouseActionPress}, want: 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDriver(t, uitest.NewSource(), 120, 40).Send(tt.msg)
+			if m := d.Model().(Model); m.cfg.LeadTime != tt.want {
+				t.Errorf("lead time = %v, want %v", m.cfg.LeadTime, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelChartYLabels(t *testing.T) {
+	m := newDriver(t, uitest.NewSource(), 120, 40).Model().(Model)
+	m.cfg.ChartYLabels = 4
+	snap := m.displaySnapshot()
+
+	// Both charts have 4 plot rows at this height, so every row is labelled
+	for name, chart := range map[string]string{
+		"quantile": m.quantileChart(snap, 80, 8).Render(snap),
+		"replica":  m.replicaChart(snap, 80).Render(snap),
+	} {
+		labels := 0
+		for _, line := range strings.Split(chart, "\n") {
+			if label, _, ok := strings.Cut(line, "┤"); ok && strings.TrimSpace(label) != "" {
+				labels++
+			}
+		}
+		if labels != 4 {
+			t.Errorf("%s chart has %d y labels, want 4", name, labels)
+		}
+	}
+}
+
+func TestModelQuantiles(t *testing.T) {
+	src := uitest.NewSource()
+	snap := src.Snapshots["checkout"]
+	p10, p90 := snap.Quantiles["p10"], snap.Quantiles["p90"]
+	p05, p95 := make([]float64, len(p10)), make([]float64, len(p90))
+	for i := range p10 {
+		p05[i], p95[i] = p10[i]-10, p90[i]+10
+	}
+	snap.Quantiles = map[string][]float64{"p05": p05, "p10": p10, "p50": snap.Quantiles["p50"], "p90": p90, "p95": p95}
+	src.Snapshots["checkout"] = snap
+
+	d := newDriver(t, src, 120, 40).Keys("p")
+	if m := d.Model().(Model); m.cfg.HighlightQuantile != "p90" {
+		t.Fatalf("highlight = %q after p, want p90", m.cfg.HighlightQuantile)
+	}
+	d.Golden("model_quantiles")
+
+	d.Keys("p", "p")
+	m := d.Model().(Model)
+	if m.cfg.HighlightQuantile != "p05" {
+		t.Fatalf("highlight = %q after wrapping past p95, want p05", m.cfg.HighlightQuantile)
+	}
+
+	records := m.tableRecords(m.displaySnapshot())
+	if got := strings.Join(records[0], ","); got != "Time,Time Offset (seconds),P05,P10,P50,P90,P95,Desired Replicas,Replica Change" {
+		t.Fatalf("header = %s", got)
+	}
+}
+
+func TestModelChartZoom(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys("z")
+	if m := d.Model().(Model); m.chartStart != 0 || m.chartSteps != 15 {
+		t.Fatalf("window = %d+%d after zooming in, want 0+15", m.chartStart, m.chartSteps)
+	}
+
+	d.Keys("shift+right").Golden("model_zoom")
+	if m := d.Model().(Model); m.chartStart != 3 {
+		t.Fatalf("window start = %d after panning right, want 3", m.chartStart)
+	}
+
+	// Moving the cursor past the window drags the window along
+	d.Keys("enter", "end", "esc")
+	if m := d.Model().(Model); m.chartStart != 15 {
+		t.Fatalf("window start = %d after moving the cursor to the end, want 15", m.chartStart)
+	}
+
+	d.Keys("Z")
+	if m := d.Model().(Model); m.chartSteps != 0 {
+		t.Fatalf("window steps = %d after zooming out, want the whole horizon", m.chartSteps)
+	}
+}
+
+func TestModelChartZoomWindow(t *testing.T) {
+	// The forecast has 30 steps and the cursor starts on step 5
+	tests := []struct {
+		name         string
+		keys         []string
+		start, steps int
+	}{
+		{name: "zoom in", keys: []string{"z"}, start: 0, steps: 15},
+		{name: "zoom in twice", keys: []string{"z", "z"}, start: 2, steps: 7},
+		{name: "zoom in to the minimum", keys: []string{"z", "z", "z", "z"}, start: 3, steps: minZoomSteps},
+		{name: "pan before the start", keys: []string{"z", "shift+left"}, start: 0, steps: 15},
+		{name: "pan a quarter window", keys: []string{"z", "z", "shift+right"}, start: 3, steps: 7},
+		{name: "pan past the end", keys: []string{"z", "shift+right", "shift+right", "shift+right", "shift+right", "shift+right", "shift+right"}, start: 15, steps: 15},
+		{name: "zoom out to the horizon", keys: []string{"z", "Z"}, start: 0, steps: 0},
+		{name: "cursor before the window", keys: []string{"z", "z", "enter", "home"}, start: 0, steps: 7},
+		{name: "cursor past the window", keys: []string{"z", "z", "enter", "end"}, start: 23, steps: 7},
+		{name: "pan without zoom", keys: []string{"shift+right"}, start: 0, steps: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDriver(t, uitest.NewSource(), 120, 40).Keys(tt.keys...).Model().(Model)
+			if m.chartStart != tt.start || m.chartSteps != tt.steps {
+				t.Errorf("window = %d+%d, want %d+%d", m.chartStart, m.chartSteps, tt.start, tt.steps)
+			}
+		})
+	}
+}
+
+func TestModelTable(t *testing.T) {
+	// The row cursor starts on the lead time, step 5
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys("2", "down", "down", "enter")
+	if m := d.Model().(Model); m.cfg.LeadTime != 7*time.Minute {
+		t.Fatalf("lead time = %v after selecting the row 2 below the lead time, want 7m", m.cfg.LeadTime)
+	}
+
+	// Sort by P50, descending, and hide the Offset column
+	d.Keys("v", "right", "right", "right", "enter", "enter", "left", "left", " ")
+	if m := d.Model().(Model); m.table.sort != (components.TableSort{Column: components.QuantileColumn("p50"), Desc: true}) || !m.table.hidden.Has(components.ColumnOffset) {
+		t.Fatalf("sort = %+v, hidden = %v after picking columns", m.table.sort, m.table.hidden)
+	}
+	d.Golden("model_table_sorted")
+	d.Keys("esc")
+
+	d.Keys("/").Type("+20m").Keys("enter")
+	if m := d.Model().(Model); m.table.cursor != 20 {
+		t.Fatalf("cursor = %d after jumping to +20m, want 20", m.table.cursor)
+	}
+
+	d.Keys("/").Type("09:31").Keys("enter")
+	if m := d.Model().(Model); m.table.cursor != 2 {
+		t.Fatalf("cursor = %d after jumping to 09:31, want 2", m.table.cursor)
+	}
+
+	d.Keys("/").Type("9").Keys("enter")
+	if m := d.Model().(Model); !m.table.prompting || m.table.inputErr == "" {
+		t.Fatalf("prompt closed after searching for a missing replica count")
+	}
+	d.Golden("model_table_prompt")
+}
+
+func TestModelTableCursorInView(t *testing.T) {
+	// Step i of the test forecast is at 09:29 plus i minutes, and the cursor
+	// starts on the lead time, step 5
+	down := func(n int) []string {
+		keys := []string{"2"}
+		for range n {
+			keys = append(keys, "down")
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name   string
+		driver func(t *testing.T) *uitest.Driver
+		row    string
+	}{
+		{
+			name: "moved past the screen",
+			driver: func(t *testing.T) *uitest.Driver {
+				return newDriver(t, uitest.NewSource(), 80, 24).Keys(down(15)...)
+			},
+			row: "> 09:49:00",
+		},
+		{
+			name: "moved to the end",
+			driver: func(t *testing.T) *uitest.Driver {
+				return newDriver(t, uitest.NewSource(), 80, 24).Keys("2", "G")
+			},
+			row: "> 09:58:00",
+		},
+		{
+			name: "shrunk under the cursor",
+			driver: func(t *testing.T) *uitest.Driver {
+				return newDriver(t, uitest.NewSource(), 160, 50).Keys(down(15)...).Resize(80, 24)
+			},
+			row: "> 09:49:00",
+		},
+		{
+			name: "sorted away from the cursor",
+			driver: func(t *testing.T) *uitest.Driver {
+				// Sort by time, descending, which moves step 5 to the bottom
+				return newDriver(t, uitest.NewSource(), 80, 24).Keys("2", "v", "enter", "enter", "esc")
+			},
+			row: "> 09:34:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if view := tt.driver(t).Model().View(); !strings.Contains(view, tt.row) {
+				t.Errorf("view does not show the cursor row %q:\n%s", tt.row, view)
+			}
+		})
+	}
+}
+
+func TestModelV1Forecast(t *testing.T) {
+	src := uitest.NewSource()
+	v1 := src.Snapshots["checkout"]
+	v1.Quantiles = nil
+	src.Snapshots["checkout"] = v1
+
+	// A v1 forecast fills the same views as a v2 one, without the quantiles
+	d := newDriver(t, src, 120, 40).Keys("2").Golden("model_tables_v1")
+	m := d.Model().(Model)
+	records := m.tableRecords(m.displaySnapshot())
+	if got := strings.Join(records[0], ","); got != "Time,Time Offset (seconds),Forecast Value,Desired Replicas,Replica Change" {
+		t.Fatalf("v1 header = %s", got)
+	}
+	if len(records) != 31 || records[3][2] != "126.00" || records[3][4] != "1" {
+		t.Fatalf("v1 records = %d, step 2 = %v", len(records)-1, records[3])
+	}
+
+	d.Keys("3").Golden("model_config_v1")
+}
+
+func TestAccuracyRecords(t *testing.T) {
+	report := &accuracy.Report{
+		Quantiles: []string{"p10", "p90"},
+		Buckets: []accuracy.Bucket{
+			{From: 0, To: 5 * time.Minute, Samples: 4, MAPE: 0.125, Coverage: 0.75, Pinball: map[string]float64{"p10": 1.5, "p90": 2}},
+			{From: 5 * time.Minute, To: 10 * time.Minute, Samples: 1, MAPE: math.NaN(), Coverage: math.NaN(), Pinball: map[string]float64{"p90": 3}},
+		},
+	}
+
+	records := accuracyRecords(report)
+	want := []string{
+		"Lead From (seconds),Lead To (seconds),Samples,MAPE P50,P10-P90 Coverage,Pinball p10,Pinball p90",
+		"0,300,4,0.1250,0.7500,1.5000,2.0000",
+		"300,600,1,,,,3.0000",
+	}
+	if len(records) != len(want) {
+		t.Fatalf("records = %d, want %d", len(records), len(want))
+	}
+	for i, record := range records {
+		if got := strings.Join(record, ","); got != want[i] {
+			t.Errorf("record %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestClipboardTextAccuracy(t *testing.T) {
+	m := newDriver(t, uitest.NewSource(), 120, 40).Model().(Model)
+	m.activeTab = TabAccuracy
+	m.accuracy = &accuracy.Report{
+		Quantiles: []string{"p90"},
+		Buckets: []accuracy.Bucket{
+			{From: 0, To: 5 * time.Minute, Samples: 1, MAPE: math.NaN(), Coverage: math.NaN(), Pinball: map[string]float64{}},
+		},
+	}
+
+	got, err := m.clipboardText()
+	if err != nil {
+		t.Fatalf("clipboardText: %v", err)
+	}
+	// NaN and missing scores copy as blanks, as in the export
+	want := "Forecast Accuracy by Lead Time:\n\n" +
+		"Lead From (seconds)\tLead To (seconds)\tSamples\tMAPE P50\tP10-P90 Coverage\tPinball p90\n" +
+		"0\t300\t1\t\t\t\n"
+	if got != want {
+		t.Errorf("clipboardText = %q, want %q", got, want)
+	}
+}
+
+func TestTableRecords(t *testing.T) {
+	m := newDriver(t, uitest.NewSource(), 120, 40).Model().(Model)
+	records := m.tableRecords(m.displaySnapshot())
+	if got := strings.Join(records[0], ","); got != "Time,Time Offset (seconds),P10,P50,P90,Desired Replicas,Replica Change" {
+		t.Fatalf("header = %s", got)
+	}
+	if got := strings.Join(records[8], ","); got != "2026-03-14T09:36:00Z,420,152.00,183.00,214.00,4,1" {
+		t.Fatalf("step 7 = %s", got)
+	}
+
+	// Observed values add the table's Actual and Error columns
+	generated := m.displaySnapshot().Snapshot.GeneratedAt
+	m.observed = &client.ObservedSeries{Samples: []client.Sample{
+		{Time: generated, Value: 98},
+		{Time: generated.Add(time.Minute), Value: 131},
+	}}
+	records = m.tableRecords(m.displaySnapshot())
+	if got := strings.Join(records[0], ","); got != "Time,Time Offset (seconds),P10,P50,P90,Actual,Error,Desired Replicas,Replica Change" {
+		t.Fatalf("header with actuals = %s", got)
+	}
+	for i, want := range []string{
+		"2026-03-14T09:29:00Z,0,90.00,100.00,110.00,98.00,-2.00,2,0",
+		"2026-03-14T09:30:00Z,60,101.00,113.00,125.00,131.00,18.00,2,0",
+		"2026-03-14T09:31:00Z,120,111.00,126.00,141.00,,,3,1",
+	} {
+		if got := strings.Join(records[i+1], ","); got != want {
+			t.Errorf("step %d = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestModelContextSwitch(t *testing.T) {
+	contexts := map[string]config.Context{
+		"staging": {ForecasterURL: "http://staging:8081"},
+		"prod":    {ForecasterURL: "http://prod:8081"},
+	}
+
+	t.Run("offline", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		uitest.PinClock(t)
+		cfg := testConfig()
+		cfg.Demo = true
+		cfg.Contexts = contexts
+
+		d := drive(t, NewModel(cfg, uitest.NewSource())).Resize(120, 40).Init().Keys("x")
+		if m := d.Model().(Model); m.showContexts {
+			t.Fatalf("context switcher opened in demo mode")
+		}
+	})
+
+	t.Run("online", func(t *testing.T) {
+		t.Setenv("HOME", t.TempDir())
+		cfg := testConfig()
+		cfg.Contexts = contexts
+		cfg.Stream = true
+
+		// The stub cannot stream; the rebuilt client can
+		m := NewModel(cfg, uitest.NewSource())
+		if m.streaming {
+			t.Fatalf("streaming from a source that cannot stream")
+		}
+		m, _ = m.switchContext("prod")
+		if _, ok := m.src.(*client.Client); !ok {
+			t.Fatalf("source = %T after switching, want *client.Client", m.src)
+		}
+		if !m.streaming || m.cfg.ForecasterURL != "http://prod:8081" {
+			t.Errorf("streaming = %v, forecaster = %s; want streaming from http://prod:8081", m.streaming, m.cfg.ForecasterURL)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/HatiCode/kedastral-tui/source"
+	"github.com/HatiCode/kedastral-tui/ui/panels"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// overviewConcurrency bounds the snapshot requests in flight at once.
+	overviewConcurrency = 4
+	overviewCardWidth   = 30
+	overviewCardHeight  = 6
+)
+
+// overviewEntry is the latest snapshot fetched for one workload.
+type overviewEntry struct {
+	data *client.QuantileSnapshotData
+	err  error
+}
+
+// fetchOverview fetches the quantile snapshot of every workload, at most
+// overviewConcurrency at a time.
+func fetchOverview(src source.ForecastSource, workloads []client.WorkloadInfo, leadTime time.Duration, gen uint64) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		entries := make(map[string]overviewEntry, len(workloads))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, overviewConcurrency)
+
+		for _, w := range workloads {
+			wg.Add(1)
+			go func(name string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				data, err := src.GetQuantileSnapshot(ctx, name, leadTime)
+
+				mu.Lock()
+				entries[name] = overviewEntry{data: data, err: err}
+				mu.Unlock()
+			}(w.Name)
+		}
+		wg.Wait()
+
+		return overviewMsg{gen: gen, entries: entries}
+	}
+}
+
+// refreshOverview starts a new overview fetch. Only the latest one is
+// applied, so a slow response cannot overwrite a newer grid.
+func (m *Model) refreshOverview() tea.Cmd {
+	m.overviewGen++
+	return fetchOverview(m.src, m.workloads, m.cfg.LeadTime, m.overviewGen)
+}
+
+func (m Model) toggleOverview() (Model, tea.Cmd) {
+	if m.showOverview {
+		m.showOverview = false
+		return m, nil
+	}
+
+	m.showOverview = true
+	m.scrubbing = false
+	for i, w := range m.workloads {
+		if w.Name == m.currentWorkload {
+			m.overviewIndex = i
+		}
+	}
+
+	if len(m.workloads) == 0 {
+		// The overview is fetched once the workload list arrives
+		return m, fetchWorkloadList(m.src)
+	}
+	return m, m.refreshOverview()
+}
+
+func (m Model) overviewColumns(width int) int {
+	return max(1, width/overviewCardWidth)
+}
+
+func (m Model) handleOverview(msg tea.KeyMsg) (Model, tea.Cmd) {
+	cols := m.overviewColumns(m.mainContentWidth())
+	n := len(m.workloads)
+
+	switch msg.String() {
+	case "esc", "escape", "o":
+		m.showOverview = false
+	case "left":
+		if m.overviewIndex > 0 {
+			m.overviewIndex--
+		}
+	case "right":
+		if m.overviewIndex < n-1 {
+			m.overviewIndex++
+		}
+	case "k", "up":
+		if m.overviewIndex >= cols {
+			m.overviewIndex -= cols
+		}
+	case "j", "down":
+		if m.overviewIndex+cols < n {
+			m.overviewIndex += cols
+		}
+	case "enter":
+		if m.overviewIndex < n {
+			m.showOverview = false
+			workload := m.workloads[m.overviewIndex].Name
+			return m, func() tea.Msg {
+				return panels.WorkloadSelectedMsg{Workload: workload}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// mainContentWidth returns the width available inside the main panel.
+func (m Model) mainContentWidth() int {
+	return m.layoutMgr.Compute().Main.W - 4
+}
+
+// renderOverview renders the workload grid and returns the line of the
+// selected card so the caller can keep it in view.
+func (m Model) renderOverview(width int) (string, int) {
+	if len(m.workloads) == 0 {
+		return mutedStyle.Render("Loading workloads..."), 0
+	}
+
+	cols := m.overviewColumns(width)
+	card := components.NewWorkloadCard(width/cols - 1)
+
+	var rows []string
+	for start := 0; start < len(m.workloads); start += cols {
+		end := min(start+cols, len(m.workloads))
+
+		cards := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			w := m.workloads[i]
+			entry := m.overview[w.Name]
+			cards = append(cards, card.Render(w, entry.data, entry.err, m.cfg.LeadTime, i == m.overviewIndex))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cards...))
+	}
+
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39")).
+		Render(fmt.Sprintf("OVERVIEW - %d workloads", len(m.workloads)))
+
+	content := header + "\n" + strings.Join(rows, "\n")
+	return content, 1 + (m.overviewIndex/cols)*overviewCardHeight
+}
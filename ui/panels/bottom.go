@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/HatiCode/kedastral-tui/config"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -32,6 +33,8 @@ type BottomPanelModel struct {
 	height     int
 	logs       []string
 	metrics    *client.ScalerMetrics
+	explorer   metricsExplorer
+	breakers   []client.BreakerStatus
 	cfg        *config.Config
 	apiVersion int
 }
@@ -46,6 +49,7 @@ func NewBottomPanel(width, height int, cfg *config.Config) BottomPanelModel {
 		width:    width,
 		height:   height,
 		logs:     make([]string, 0, 1000),
+		explorer: newMetricsExplorer(),
 		cfg:      cfg,
 	}
 }
@@ -55,6 +59,12 @@ func (b BottomPanelModel) Update(msg tea.Msg) (BottomPanelModel, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if b.mode == BottomMetrics {
+			if handled := b.handleExplorerKey(msg.String()); handled {
+				return b, nil
+			}
+		}
+
 		switch msg.String() {
 		case "b":
 			// Cycle through modes
@@ -98,11 +108,68 @@ func (b *BottomPanelModel) SetSize(width, height int) {
 
 func (b *BottomPanelModel) UpdateMetrics(metrics *client.ScalerMetrics) {
 	b.metrics = metrics
+	if metrics != nil {
+		b.explorer.update(metrics.Families, clock.Now())
+	}
 	if b.mode == BottomMetrics {
 		b.updateViewportContent()
 	}
 }
 
+// ResetMetrics clears scaler metrics and their history, e.g. when the
+// workload changes.
+func (b *BottomPanelModel) ResetMetrics() {
+	b.metrics = nil
+	b.explorer.reset()
+	if b.mode == BottomMetrics {
+		b.updateViewportContent()
+	}
+}
+
+// Capturing reports whether the panel is taking text input, in which case
+// keys should not trigger global shortcuts.
+func (b BottomPanelModel) Capturing() bool {
+	return b.mode == BottomMetrics && b.explorer.editing
+}
+
+// handleExplorerKey handles keys for the metrics explorer and reports
+// whether the key was consumed.
+func (b *BottomPanelModel) handleExplorerKey(key string) bool {
+	if b.explorer.editing {
+		b.explorer.handleInput(key)
+		b.updateViewportContent()
+		return true
+	}
+
+	switch key {
+	case "/":
+		b.explorer.editing = true
+	case "esc", "escape":
+		if b.explorer.filter == "" {
+			return false
+		}
+		b.explorer.filter = ""
+		b.explorer.clampSelection()
+	case "j", "down":
+		b.explorer.move(1)
+	case "k", "up":
+		b.explorer.move(-1)
+	default:
+		return false
+	}
+
+	b.updateViewportContent()
+	return true
+}
+
+// UpdateBreakers sets the circuit breaker states shown in the Info view.
+func (b *BottomPanelModel) UpdateBreakers(breakers []client.BreakerStatus) {
+	b.breakers = breakers
+	if b.mode == BottomInfo {
+		b.updateViewportContent()
+	}
+}
+
 func (b *BottomPanelModel) UpdateAPIVersion(version int) {
 	b.apiVersion = version
 	if b.mode == BottomInfo {
@@ -111,7 +178,7 @@ func (b *BottomPanelModel) UpdateAPIVersion(version int) {
 }
 
 func (b *BottomPanelModel) addLog(log string) {
-	timestamp := time.Now().Format("15:04:05")
+	timestamp := clock.Now().Format("15:04:05")
 	entry := fmt.Sprintf("[%s] %s", timestamp, log)
 
 	b.logs = append(b.logs, entry)
@@ -128,7 +195,15 @@ func (b *BottomPanelModel) updateViewportContent() {
 	case BottomLogs:
 		content = b.renderLogs()
 	case BottomMetrics:
-		content = b.renderMetrics()
+		var selectedLine int
+		content, selectedLine = b.renderMetrics()
+		b.viewport.SetContent(content)
+
+		// Keep the selected family header in view
+		if selectedLine < b.viewport.YOffset || selectedLine >= b.viewport.YOffset+b.viewport.Height {
+			b.viewport.SetYOffset(selectedLine)
+		}
+		return
 	case BottomEvents:
 		content = b.renderEvents()
 	case BottomInfo:
@@ -168,9 +243,9 @@ func (b *BottomPanelModel) renderLogs() string {
 	return strings.Join(b.logs[start:], "\n")
 }
 
-func (b *BottomPanelModel) renderMetrics() string {
+func (b *BottomPanelModel) renderMetrics() (string, int) {
 	if b.metrics == nil {
-		return "No metrics available"
+		return "No metrics available", 0
 	}
 
 	var s strings.Builder
@@ -178,28 +253,25 @@ func (b *BottomPanelModel) renderMetrics() string {
 	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
 
-	s.WriteString(titleStyle.Render("Scaler Metrics"))
-	s.WriteString("\n\n")
+	s.WriteString(titleStyle.Render(fmt.Sprintf("Scaler Metrics (%d families)", b.metrics.Families.Len())))
+	s.WriteString("\n")
 
-	status := "Active: "
+	active := errorStyle.Render("✗")
 	if b.metrics.Active {
-		status += successStyle.Render("✓ Yes")
-	} else {
-		status += errorStyle.Render("✗ No")
+		active = successStyle.Render("✓")
 	}
-	s.WriteString(status + "\n")
-	s.WriteString(fmt.Sprintf("Desired replicas: %d\n", b.metrics.DesiredReplicas))
-	s.WriteString(fmt.Sprintf("Forecast age seen: %.1fs\n", b.metrics.ForecastAgeSeen))
-
-	connection := "Connection: "
+	connection := errorStyle.Render("✗")
 	if b.metrics.ConnectionHealthy {
-		connection += successStyle.Render("✓ Healthy")
-	} else {
-		connection += errorStyle.Render("✗ Unhealthy")
+		connection = successStyle.Render("✓")
 	}
-	s.WriteString(connection + "\n")
+	s.WriteString(fmt.Sprintf("Active: %s  Desired replicas: %d  Forecast age seen: %.1fs  Connection: %s\n\n",
+		active, b.metrics.DesiredReplicas, b.metrics.ForecastAgeSeen, connection))
 
-	return s.String()
+	offset := strings.Count(s.String(), "\n")
+	explorer, selectedLine := b.explorer.render(b.viewport.Width)
+	s.WriteString(explorer)
+
+	return s.String(), offset + selectedLine
 }
 
 func (b *BottomPanelModel) renderEvents() string {
@@ -225,6 +297,9 @@ func (b *BottomPanelModel) renderInfo() string {
 	s.WriteString("\n\n")
 
 	if b.cfg != nil {
+		if b.cfg.CurrentContext != "" {
+			s.WriteString(fmt.Sprintf("Context:         %s\n", b.cfg.CurrentContext))
+		}
 		s.WriteString(fmt.Sprintf("Forecaster URL:  %s\n", b.cfg.ForecasterURL))
 		s.WriteString(fmt.Sprintf("Scaler URL:      %s\n", b.cfg.ScalerURL))
 		s.WriteString(fmt.Sprintf("API Version:     v%d\n", b.apiVersion))
@@ -232,6 +307,27 @@ func (b *BottomPanelModel) renderInfo() string {
 		s.WriteString(fmt.Sprintf("Lead Time:       %s\n", b.cfg.LeadTime))
 	}
 
+	if len(b.breakers) > 0 {
+		s.WriteString("\n")
+		s.WriteString(titleStyle.Render("Circuit Breakers"))
+		s.WriteString("\n\n")
+
+		for _, breaker := range b.breakers {
+			icon := checkmark
+			if breaker.State != client.BreakerClosed {
+				icon = xmark
+			}
+			line := fmt.Sprintf("%s %-11s %-9s %d failures", icon, breaker.Endpoint, breaker.State, breaker.Failures)
+			if breaker.State == client.BreakerOpen {
+				line += fmt.Sprintf("  next probe %s", breaker.NextProbe.Format("15:04:05"))
+				if wait := clock.Until(breaker.NextProbe); wait > 0 {
+					line += fmt.Sprintf(" (in %s)", wait.Round(time.Second))
+				}
+			}
+			s.WriteString(line + "\n")
+		}
+	}
+
 	s.WriteString("\n")
 	s.WriteString(titleStyle.Render("Features"))
 	s.WriteString("\n\n")
@@ -241,7 +337,7 @@ func (b *BottomPanelModel) renderInfo() string {
 		quantilesIcon = checkmark
 	}
 
-	s.WriteString(fmt.Sprintf("%s Quantile forecasts (P10/P50/P90)\n", quantilesIcon))
+	s.WriteString(fmt.Sprintf("%s Quantile forecasts with nested bands\n", quantilesIcon))
 	s.WriteString(fmt.Sprintf("%s Multi-workload switching\n", checkmark))
 	s.WriteString(fmt.Sprintf("%s Tab-based views\n", checkmark))
 	s.WriteString(fmt.Sprintf("%s Bottom panel (logs/metrics/events/info)\n", checkmark))
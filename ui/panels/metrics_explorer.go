@@ -0,0 +1,348 @@
+package panels
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// explorerHistory is the number of scrapes kept per series for sparklines.
+const explorerHistory = 60
+
+type seriesPoint struct {
+	value float64
+	at    time.Time
+}
+
+// metricsExplorer browses every metric family scraped from the scaler.
+type metricsExplorer struct {
+	families []*promtext.Family
+	history  map[string][]seriesPoint
+	selected int
+	filter   string
+	editing  bool
+}
+
+func newMetricsExplorer() metricsExplorer {
+	return metricsExplorer{history: make(map[string][]seriesPoint)}
+}
+
+// update records a scrape. Samples without a timestamp are stamped with now.
+func (e *metricsExplorer) update(set *promtext.Set, now time.Time) {
+	e.families = set.Families()
+
+	history := make(map[string][]seriesPoint, len(e.history))
+	for _, f := range e.families {
+		for _, s := range f.Samples {
+			key := s.Key()
+			at := s.Timestamp
+			if at.IsZero() {
+				at = now
+			}
+
+			points := e.history[key]
+			if n := len(points); n > 0 && !at.After(points[n-1].at) {
+				points[n-1].value = s.Value
+			} else {
+				points = append(points, seriesPoint{value: s.Value, at: at})
+			}
+			if len(points) > explorerHistory {
+				points = points[len(points)-explorerHistory:]
+			}
+			history[key] = points
+		}
+	}
+	e.history = history
+
+	e.clampSelection()
+}
+
+func (e *metricsExplorer) reset() {
+	*e = newMetricsExplorer()
+}
+
+func (e *metricsExplorer) move(delta int) {
+	e.selected += delta
+	e.clampSelection()
+}
+
+func (e *metricsExplorer) clampSelection() {
+	n := len(e.visibleFamilies())
+	if e.selected >= n {
+		e.selected = n - 1
+	}
+	if e.selected < 0 {
+		e.selected = 0
+	}
+}
+
+// handleInput edits the filter while typing.
+func (e *metricsExplorer) handleInput(key string) {
+	switch key {
+	case "enter":
+		e.editing = false
+	case "esc", "escape":
+		e.editing = false
+		e.filter = ""
+	case "backspace":
+		if len(e.filter) > 0 {
+			e.filter = e.filter[:len(e.filter)-1]
+		}
+	default:
+		if len(key) == 1 {
+			e.filter += key
+		} else if key == "space" {
+			e.filter += " "
+		}
+	}
+	e.clampSelection()
+}
+
+// parseFilter splits the filter into label matchers (name=value) and family
+// name substrings.
+func parseFilter(filter string) (promtext.Labels, []string) {
+	labels := promtext.Labels{}
+	var names []string
+
+	for _, term := range strings.FieldsFunc(filter, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if name, value, ok := strings.Cut(term, "="); ok && name != "" {
+			labels[name] = strings.Trim(value, `"`)
+		} else {
+			names = append(names, term)
+		}
+	}
+
+	return labels, names
+}
+
+// visibleFamilies returns the families that match the filter, with their
+// samples narrowed to the matching series.
+func (e *metricsExplorer) visibleFamilies() []*promtext.Family {
+	labels, names := parseFilter(e.filter)
+
+	var visible []*promtext.Family
+	for _, f := range e.families {
+		matched := true
+		for _, name := range names {
+			if !strings.Contains(f.Name, name) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		filtered := &promtext.Family{Name: f.Name, Help: f.Help, Type: f.Type}
+		for _, s := range f.Samples {
+			if s.Labels.Matches(labels) {
+				filtered.Samples = append(filtered.Samples, s)
+			}
+		}
+		if len(filtered.Samples) > 0 {
+			visible = append(visible, filtered)
+		}
+	}
+
+	return visible
+}
+
+// isCounter reports whether a sample only ever increases, so a rate is
+// meaningful.
+func isCounter(f *promtext.Family, s promtext.Sample) bool {
+	switch f.Type {
+	case promtext.Counter:
+		return true
+	case promtext.Histogram, promtext.Summary:
+		return strings.HasSuffix(s.Name, "_sum") || strings.HasSuffix(s.Name, "_count")
+	}
+	return false
+}
+
+// rates returns the per-second rate between consecutive points, treating a
+// decrease as a counter reset.
+func rates(points []seriesPoint) []float64 {
+	if len(points) < 2 {
+		return nil
+	}
+
+	out := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		dt := points[i].at.Sub(points[i-1].at).Seconds()
+		if dt <= 0 {
+			out = append(out, math.NaN())
+			continue
+		}
+		delta := points[i].value - points[i-1].value
+		if delta < 0 {
+			delta = points[i].value
+		}
+		out = append(out, delta/dt)
+	}
+	return out
+}
+
+func (e *metricsExplorer) rate(key string) (float64, bool) {
+	r := rates(e.history[key])
+	if len(r) == 0 || math.IsNaN(r[len(r)-1]) {
+		return 0, false
+	}
+	return r[len(r)-1], true
+}
+
+// render draws the explorer and returns the line of the selected family so
+// the caller can keep it in view.
+func (e *metricsExplorer) render(width int) (string, int) {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+
+	var lines []string
+
+	switch {
+	case e.editing:
+		lines = append(lines, "Filter: "+inputStyle.Render(e.filter+"█")+mutedStyle.Render("  (enter to apply, esc to clear)"))
+	case e.filter != "":
+		lines = append(lines, "Filter: "+inputStyle.Render(e.filter)+mutedStyle.Render("  (/ to edit, esc to clear)"))
+	default:
+		lines = append(lines, mutedStyle.Render("/ filter by name or label=value  ↑/↓ select family"))
+	}
+	lines = append(lines, "")
+
+	visible := e.visibleFamilies()
+	if len(visible) == 0 {
+		lines = append(lines, mutedStyle.Render("No metric families match"))
+		return strings.Join(lines, "\n"), 0
+	}
+
+	selectedLine := 0
+	for i, f := range visible {
+		header := fmt.Sprintf("%s  %s  %d series", f.Name, f.Type, len(f.Samples))
+		if i != e.selected {
+			lines = append(lines, "▸ "+header)
+			continue
+		}
+
+		selectedLine = len(lines)
+		lines = append(lines, selectedStyle.Render("▾ "+header))
+		lines = append(lines, e.renderFamily(f, width)...)
+	}
+
+	return strings.Join(lines, "\n"), selectedLine
+}
+
+func (e *metricsExplorer) renderFamily(f *promtext.Family, width int) []string {
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	sparkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+
+	var lines []string
+	if f.Help != "" {
+		lines = append(lines, "  "+mutedStyle.Render(f.Help))
+	}
+
+	if breakdown := e.statusBreakdown(f); breakdown != "" {
+		lines = append(lines, "  Rate by status: "+breakdown)
+	}
+
+	labelWidth := max(20, width*2/5)
+	sparkWidth := max(10, width-labelWidth-30)
+	spark := components.NewSparkline(sparkWidth)
+
+	buckets := 0
+	for _, s := range f.Samples {
+		if strings.HasSuffix(s.Name, "_bucket") && f.Type == promtext.Histogram {
+			buckets++
+			continue
+		}
+
+		key := s.Key()
+		label := key
+		if s.Name == f.Name {
+			label = s.Labels.String()
+			if label == "" {
+				label = "{}"
+			}
+		}
+		if len(label) > labelWidth {
+			label = label[:labelWidth-3] + "..."
+		}
+
+		values := make([]float64, 0, len(e.history[key]))
+		for _, p := range e.history[key] {
+			values = append(values, p.value)
+		}
+
+		rateCol := ""
+		if isCounter(f, s) {
+			values = rates(e.history[key])
+			if r, ok := e.rate(key); ok {
+				rateCol = formatMetricValue(r) + "/s"
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("  %-*s  %12s  %10s  %s",
+			labelWidth, label, formatMetricValue(s.Value), rateCol, sparkStyle.Render(spark.Render(values))))
+	}
+
+	if buckets > 0 {
+		lines = append(lines, "  "+mutedStyle.Render(fmt.Sprintf("%d histogram buckets hidden", buckets)))
+	}
+
+	return lines
+}
+
+// statusBreakdown sums counter rates by status label, or returns "" if the
+// family has no status label.
+func (e *metricsExplorer) statusBreakdown(f *promtext.Family) string {
+	if f.Type != promtext.Counter {
+		return ""
+	}
+
+	byStatus := make(map[string]float64)
+	for _, s := range f.Samples {
+		status, ok := s.Labels["status"]
+		if !ok {
+			continue
+		}
+		r, _ := e.rate(s.Key())
+		byStatus[status] += r
+	}
+	if len(byStatus) == 0 {
+		return ""
+	}
+
+	statuses := make([]string, 0, len(byStatus))
+	for status := range byStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("%s %s/s", status, formatMetricValue(byStatus[status]))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func formatMetricValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case v == math.Trunc(v) && math.Abs(v) < 1e12:
+		return fmt.Sprintf("%.0f", v)
+	case math.Abs(v) >= 1:
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return fmt.Sprintf("%.3g", v)
+	}
+}
@@ -0,0 +1,113 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client/promtext"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+)
+
+const explorerMetrics = `# TYPE http_requests_total counter
+http_requests_total{code="200",handler="/api"} 100
+http_requests_total{code="500",handler="/api"} 4
+http_requests_total{code="200",handler="/healthz"} 50
+# TYPE queue_depth gauge
+queue_depth{queue="orders"} 7
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="+Inf"} 10
+request_duration_seconds_sum 2.5
+request_duration_seconds_count 10
+`
+
+func TestRates(t *testing.T) {
+	at := func(seconds int, value float64) seriesPoint {
+		return seriesPoint{value: value, at: uitest.Now.Add(time.Duration(seconds) * time.Second)}
+	}
+
+	tests := []struct {
+		name   string
+		points []seriesPoint
+		want   string
+	}{
+		{name: "increasing", points: []seriesPoint{at(0, 10), at(10, 30), at(15, 40)}, want: "[2 2]"},
+		{name: "flat", points: []seriesPoint{at(0, 10), at(5, 10)}, want: "[0]"},
+		// After a reset the counter restarted from zero, so its new value is
+		// the increase
+		{name: "counter reset", points: []seriesPoint{at(0, 100), at(10, 20), at(20, 40)}, want: "[2 2]"},
+		{name: "same timestamp", points: []seriesPoint{at(0, 10), at(0, 20)}, want: "[NaN]"},
+		{name: "single point", points: []seriesPoint{at(0, 10)}, want: "[]"},
+		{name: "no points", want: "[]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(rates(tt.points)); got != tt.want {
+				t.Errorf("rates = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		labels string
+		names  string
+	}{
+		{filter: "", labels: "", names: "[]"},
+		{filter: "http", labels: "", names: "[http]"},
+		{filter: `code="500"`, labels: `{code="500"}`, names: "[]"},
+		{filter: "http total code=200,handler=/api", labels: `{code="200",handler="/api"}`, names: "[http total]"},
+		// A term without a label name is a name substring
+		{filter: "=200", labels: "", names: "[=200]"},
+		{filter: " , queue ,", labels: "", names: "[queue]"},
+	}
+
+	for _, tt := range tests {
+		labels, names := parseFilter(tt.filter)
+		if got := labels.String(); got != tt.labels {
+			t.Errorf("parseFilter(%q) labels = %s, want %s", tt.filter, got, tt.labels)
+		}
+		if got := fmt.Sprint(names); got != tt.names {
+			t.Errorf("parseFilter(%q) names = %s, want %s", tt.filter, got, tt.names)
+		}
+	}
+}
+
+func TestVisibleFamilies(t *testing.T) {
+	set, err := promtext.Parse(strings.NewReader(explorerMetrics))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		filter string
+		want   []string // "family:samples"
+	}{
+		{filter: "", want: []string{"http_requests_total:3", "queue_depth:1", "request_duration_seconds:3"}},
+		{filter: "request", want: []string{"http_requests_total:3", "request_duration_seconds:3"}},
+		{filter: "http total", want: []string{"http_requests_total:3"}},
+		{filter: "handler=/api", want: []string{"http_requests_total:2"}},
+		{filter: `code="500" handler=/api`, want: []string{"http_requests_total:1"}},
+		{filter: "queue=orders depth", want: []string{"queue_depth:1"}},
+		{filter: "code=404"},
+		{filter: "missing"},
+	}
+
+	for _, tt := range tests {
+		e := newMetricsExplorer()
+		e.update(set, uitest.Now)
+		e.filter = tt.filter
+
+		var got []string
+		for _, f := range e.visibleFamilies() {
+			got = append(got, fmt.Sprintf("%s:%d", f.Name, len(f.Samples)))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("filter %q: visibleFamilies = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
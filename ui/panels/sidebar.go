@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -159,7 +160,7 @@ func formatAge(t time.Time) string {
 		return "---"
 	}
 
-	age := time.Since(t)
+	age := clock.Since(t)
 
 	if age < time.Minute {
 		return fmt.Sprintf("%ds", int(age.Seconds()))
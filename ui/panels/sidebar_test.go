@@ -0,0 +1,59 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func sidebarWorkloads() []client.WorkloadInfo {
+	return []client.WorkloadInfo{
+		{Name: "checkout", LastForecast: uitest.Now.Add(-30 * time.Second), Healthy: true},
+		{Name: "search", LastForecast: uitest.Now.Add(-20 * time.Minute), Healthy: false},
+		{Name: "payments", LastForecast: uitest.Now.Add(-5 * time.Hour), Healthy: true},
+		{Name: "recommendations", LastForecast: uitest.Now.Add(-72 * time.Hour), Healthy: true},
+		{Name: "notifications"},
+	}
+}
+
+func TestWorkloadDelegateGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	workloads := sidebarWorkloads()
+	items := make([]list.Item, len(workloads))
+	for i, w := range workloads {
+		items[i] = workloadItem{info: w}
+	}
+
+	delegate := newWorkloadDelegate()
+	l := list.New(items, delegate, 40, 20)
+	l.Select(1)
+
+	var b strings.Builder
+	for i, item := range items {
+		delegate.Render(&b, l, i, item)
+	}
+	uitest.Golden(t, "workload_delegate", b.String())
+}
+
+func TestSidebarGolden(t *testing.T) {
+	uitest.PinClock(t)
+
+	for _, size := range []struct{ width, height int }{{24, 10}, {34, 16}, {50, 24}} {
+		for _, moves := range []int{0, 3} {
+			name := fmt.Sprintf("sidebar_%dx%d_down%d", size.width, size.height, moves)
+			t.Run(name, func(t *testing.T) {
+				sidebar := NewSidebar(sidebarWorkloads(), size.width, size.height)
+				for range moves {
+					sidebar, _ = sidebar.Update(uitest.Key("down"))
+				}
+				uitest.Golden(t, name, sidebar.View())
+			})
+		}
+	}
+}
@@ -14,6 +14,7 @@ const (
 	TabTables
 	TabConfig
 	TabLogs
+	TabAccuracy
 )
 
 type TabSwitchMsg struct {
@@ -51,6 +52,7 @@ func NewTabBar(width int) TabBarModel {
 			{ID: TabTables, Title: "Tables", Icon: "▤"},
 			{ID: TabConfig, Title: "Config", Icon: "⚙"},
 			{ID: TabLogs, Title: "Logs", Icon: "≡"},
+			{ID: TabAccuracy, Title: "Accuracy", Icon: "◎"},
 		},
 		width:     width,
 		activeIdx: 0,
@@ -73,6 +75,9 @@ func (t TabBarModel) Update(msg tea.Msg) (TabBarModel, tea.Cmd) {
 		case "4":
 			t.activeIdx = 3
 			return t, t.emitTabSwitch()
+		case "5":
+			t.activeIdx = 4
+			return t, t.emitTabSwitch()
 		case "h", "left":
 			if t.activeIdx > 0 {
 				t.activeIdx--
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/HatiCode/kedastral-tui/history"
+	"github.com/HatiCode/kedastral-tui/session"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	replayTickInterval = 100 * time.Millisecond
+	replaySeekStep     = time.Minute
+	replayMinSpeed     = 0.125
+	replayMaxSpeed     = 64
+)
+
+// replayState plays back a recorded session against the recorded clock.
+type replayState struct {
+	records  []session.Record
+	pos      int       // Index of the next record to apply
+	clock    time.Time // Recorded time reached by playback
+	speed    float64
+	lastTick time.Time
+}
+
+type replayTickMsg time.Time
+
+func replayTick() tea.Cmd {
+	return after(replayTickInterval, func(t time.Time) tea.Msg {
+		return replayTickMsg(t)
+	})
+}
+
+// WithReplay makes the model play back records instead of fetching data.
+func (m Model) WithReplay(records []session.Record, speed float64) Model {
+	m.replay = &replayState{
+		records: records,
+		clock:   records[0].Time,
+		speed:   speed,
+	}
+	m.streaming = false
+
+	for _, rec := range records {
+		if rec.Workload != "" {
+			m.currentWorkload = rec.Workload
+			break
+		}
+	}
+
+	return m
+}
+
+// WithRecorder makes the model record every result it receives.
+func (m Model) WithRecorder(r *session.Recorder) Model {
+	m.recorder = r
+	return m
+}
+
+// record writes rec to the session recording, if any. Recording stops after
+// the first write error.
+func (m *Model) record(rec session.Record, err error) {
+	if m.recorder == nil || m.replay != nil {
+		return
+	}
+
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if err := m.recorder.Write(rec); err != nil {
+		m.recorder = nil
+		m.toastManager.Add(fmt.Sprintf("Recording stopped: %v", err), components.ToastError, 5*time.Second)
+	}
+}
+
+// advanceReplay moves the playback clock and applies every record it passed.
+func (m Model) advanceReplay(now time.Time) (Model, tea.Cmd) {
+	r := m.replay
+	if m.mode == ModeLive && !r.lastTick.IsZero() {
+		r.clock = r.clock.Add(time.Duration(float64(now.Sub(r.lastTick)) * r.speed))
+	}
+	r.lastTick = now
+
+	cmds := []tea.Cmd{replayTick()}
+	for r.pos < len(r.records) && !r.records[r.pos].Time.After(r.clock) {
+		var cmd tea.Cmd
+		m, cmd = m.applyRecord(r.records[r.pos])
+		r.pos++
+		cmds = append(cmds, cmd)
+	}
+
+	if r.pos >= len(r.records) && m.mode == ModeLive {
+		m.mode = ModePaused
+		m.toastManager.Add("Replay finished", components.ToastInfo, 3*time.Second)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// applyRecord feeds a recorded result through Update as if it had just been
+// fetched.
+func (m Model) applyRecord(rec session.Record) (Model, tea.Cmd) {
+	if rec.Workload != "" && rec.Workload != m.currentWorkload {
+		m.currentWorkload = rec.Workload
+		m.resetWorkloadData()
+	}
+
+	var err error
+	if rec.Error != "" {
+		err = errors.New(rec.Error)
+	}
+
+	var msg tea.Msg
+	switch rec.Kind {
+	case session.KindSnapshot:
+		msg = quantileSnapshotMsg{workload: rec.Workload, gen: m.fetchGen, data: rec.Snapshot, err: err}
+	case session.KindScalerMetrics:
+		msg = scalerMetricsMsg{workload: rec.Workload, gen: m.fetchGen, data: rec.Metrics, err: err}
+	case session.KindHealth:
+		source := sourceForecasterHealth
+		if rec.Endpoint == session.EndpointScaler {
+			source = sourceScalerHealth
+		}
+		msg = healthMsg{source: source, gen: m.fetchGen, err: err}
+	case session.KindWorkloads:
+		msg = workloadListMsg{workloads: rec.Workloads, err: err}
+	default:
+		return m, nil
+	}
+
+	next, cmd := m.Update(msg)
+	return next.(Model), cmd
+}
+
+// seekReplay rebuilds the model state as of the first n records. Seeking
+// backwards replays the recording from the start.
+func (m Model) seekReplay(n int) Model {
+	r := m.replay
+	n = max(0, min(n, len(r.records)))
+
+	if n < r.pos {
+		m.resetWorkloadData()
+		m.history = history.NewStore(m.cfg.HistorySize)
+		m.sources = [sourceCount]sourceState{}
+		m.forecasterHealthy = false
+		m.scalerHealthy = false
+		r.pos = 0
+	}
+
+	for r.pos < n {
+		// Intermediate logs and commands would flood the UI while seeking
+		m, _ = m.applyRecord(r.records[r.pos])
+		r.pos++
+	}
+
+	if n > 0 {
+		r.clock = r.records[n-1].Time
+	} else {
+		r.clock = r.records[0].Time
+	}
+	return m
+}
+
+// seekReplayTime seeks to the last record at or before t.
+func (m Model) seekReplayTime(t time.Time) Model {
+	r := m.replay
+	n := 0
+	for n < len(r.records) && !r.records[n].Time.After(t) {
+		n++
+	}
+	m = m.seekReplay(n)
+	if t.After(r.clock) && n < len(r.records) {
+		r.clock = t
+	}
+	return m
+}
+
+// handleReplayKey handles playback controls and blocks actions that would
+// reach the network. It reports whether the key was consumed.
+func (m Model) handleReplayKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	r := m.replay
+
+	switch msg.String() {
+	case " ":
+		if m.showHelp || m.mode != ModePaused || r.pos < len(r.records) {
+			return m, nil, false
+		}
+		// Play again from the start once finished
+		m = m.seekReplay(0)
+		m.mode = ModeLive
+	case ",":
+		m = m.seekReplay(r.pos - 1)
+	case ".":
+		m = m.seekReplay(r.pos + 1)
+	case "<":
+		m = m.seekReplayTime(r.clock.Add(-replaySeekStep))
+	case ">":
+		m = m.seekReplayTime(r.clock.Add(replaySeekStep))
+	case "+", "=":
+		r.speed = min(r.speed*2, replayMaxSpeed)
+		m.toastManager.Add(fmt.Sprintf("Replay speed: %gx", r.speed), components.ToastInfo, 2*time.Second)
+	case "-", "_":
+		r.speed = max(r.speed/2, replayMinSpeed)
+		m.toastManager.Add(fmt.Sprintf("Replay speed: %gx", r.speed), components.ToastInfo, 2*time.Second)
+	case "r", "ctrl+r", "x", "o":
+		m.toastManager.Add("Not available while replaying", components.ToastWarning, 2*time.Second)
+	default:
+		return m, nil, false
+	}
+
+	return m, nil, true
+}
+
+// replayStatus describes the playback position for the status bar.
+func (m Model) replayStatus() string {
+	if m.replay == nil {
+		return ""
+	}
+	r := m.replay
+	return fmt.Sprintf("%s  %d/%d  %gx", r.clock.Format("15:04:05"), r.pos, len(r.records), r.speed)
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	"github.com/HatiCode/kedastral-tui/session"
+)
+
+func TestRecordReplay(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	uitest.PinClock(t)
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	recorder, err := session.NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	drive(t, NewModel(testConfig(), uitest.NewSource()).WithRecorder(recorder)).Resize(120, 40).Init()
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := session.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	kinds := make(map[session.Kind]int)
+	for _, rec := range records {
+		kinds[rec.Kind]++
+		if !rec.Time.Equal(uitest.Now) {
+			t.Errorf("%s record stamped %v, want the pinned clock %v", rec.Kind, rec.Time, uitest.Now)
+		}
+	}
+	want := map[session.Kind]int{session.KindSnapshot: 1, session.KindScalerMetrics: 1, session.KindHealth: 2, session.KindWorkloads: 1}
+	for kind, n := range want {
+		if kinds[kind] != n {
+			t.Errorf("recorded %d %s records, want %d", kinds[kind], kind, n)
+		}
+	}
+
+	// The replay reaches nothing but the recording
+	model := NewModel(testConfig(), &uitest.Source{}).WithReplay(records, 1)
+	d := drive(t, model).Resize(120, 40).Init()
+
+	// A single tick applies every record, as they share a timestamp
+	next, _ := d.Model().Update(replayTickMsg(uitest.Now))
+	m := next.(Model)
+	if m.replay.pos != len(records) {
+		t.Fatalf("replayed %d of %d records", m.replay.pos, len(records))
+	}
+	if len(m.workloads) != 2 || m.sidebar == nil {
+		t.Errorf("replay has %d workloads, sidebar = %v; want both recorded workloads listed", len(m.workloads), m.sidebar != nil)
+	}
+	if m.quantileSnapshot == nil || m.scalerMetrics == nil {
+		t.Errorf("replay missing data: snapshot = %v, metrics = %v", m.quantileSnapshot != nil, m.scalerMetrics != nil)
+	}
+	if !m.forecasterHealthy || !m.scalerHealthy {
+		t.Errorf("health = %v, %v; want both healthy", m.forecasterHealthy, m.scalerHealthy)
+	}
+	if m.currentWorkload != "checkout" {
+		t.Errorf("workload = %q, want checkout", m.currentWorkload)
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
+	"github.com/HatiCode/kedastral-tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scrubbable reports whether the active tab can show historical snapshots.
+func (m Model) scrubbable() bool {
+	return m.activeTab == TabCharts || m.activeTab == TabTables
+}
+
+func (m Model) toggleScrub() (Model, tea.Cmd) {
+	if m.scrubbing {
+		m.scrubbing = false
+		return m, nil
+	}
+
+	if !m.scrubbable() {
+		return m, nil
+	}
+
+	n := m.history.Len(m.currentWorkload)
+	if n == 0 {
+		m.toastManager.Add("No snapshot history yet", components.ToastWarning, 2*time.Second)
+		return m, nil
+	}
+
+	m.scrubbing = true
+	m.scrubIndex = n - 1
+	return m, nil
+}
+
+func (m Model) handleScrub(msg tea.KeyMsg) (Model, tea.Cmd) {
+	n := m.history.Len(m.currentWorkload)
+
+	switch msg.String() {
+	case "left":
+		if m.scrubIndex > 0 {
+			m.scrubIndex--
+		}
+	case "right":
+		if m.scrubIndex < n-1 {
+			m.scrubIndex++
+		}
+	case "home":
+		m.scrubIndex = 0
+	case "end":
+		m.scrubIndex = n - 1
+	case "esc", "escape", "s":
+		m.scrubbing = false
+	}
+
+	return m, nil
+}
+
+// displaySnapshot returns the snapshot the Charts and Tables tabs should
+// render: the scrubbed history entry when scrubbing, otherwise the latest.
+// Its lead time index follows the chart cursor.
+func (m Model) displaySnapshot() *client.QuantileSnapshotData {
+	if m.scrubbing {
+		if ring := m.history.Get(m.currentWorkload); ring != nil {
+			if snap := ring.At(m.scrubIndex); snap != nil {
+				return m.withLeadTime(snap)
+			}
+		}
+	}
+	return m.withLeadTime(m.quantileSnapshot)
+}
+
+// scrubStatus describes the scrub position for the status bar.
+func (m Model) scrubStatus() string {
+	if !m.scrubbing {
+		return ""
+	}
+
+	ring := m.history.Get(m.currentWorkload)
+	if ring == nil {
+		return ""
+	}
+
+	snap := ring.At(m.scrubIndex)
+	if snap == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("viewing snapshot %d of %d (generated %s)",
+		m.scrubIndex+1,
+		ring.Len(),
+		clock.Local(snap.Snapshot.GeneratedAt).Format("15:04:05"),
+	)
+}
@@ -1,10 +1,7 @@
 package ui
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/HatiCode/kedastral-tui/config"
@@ -147,32 +144,16 @@ func (m SetupModel) View() string {
 }
 
 func (m *SetupModel) saveConfig() error {
-	cfg := &config.Config{
-		ForecasterURL: m.forecasterURL,
-		ScalerURL:     m.scalerURL,
-		Workload:      m.workload,
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
-	}
-
-	configDir := filepath.Join(homeDir, ".config", "kedastral-tui")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-
-	configPath := filepath.Join(configDir, "config.json")
-
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	// Merge into the existing file so contexts and preferences survive setup
+	cfg, err := config.LoadConfigFile()
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		cfg = &config.Config{}
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
+	cfg.ForecasterURL = m.forecasterURL
+	cfg.ScalerURL = m.scalerURL
+	cfg.Workload = m.workload
+	cfg.CurrentContext = ""
 
-	return nil
+	return config.SaveConfig(cfg)
 }
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+)
+
+func TestSetupWizardGolden(t *testing.T) {
+	for _, size := range []struct{ width, height int }{{40, 12}, {80, 24}, {120, 40}} {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			golden := func(step string) string {
+				return fmt.Sprintf("setup_%s_%dx%d", step, size.width, size.height)
+			}
+
+			d := uitest.New(t, NewSetupModel()).Resize(size.width, size.height)
+			d.Golden(golden("forecaster"))
+
+			d.Type("http://forecaster:8081").Golden(golden("forecaster_typed"))
+
+			// The scaler step starts with the default URL filled in
+			d.Keys("enter").Golden(golden("scaler"))
+
+			d.Keys("enter").Type("checkoutx").Keys("backspace").Golden(golden("workload"))
+
+			d.Keys("enter")
+			if !d.Quit() {
+				t.Fatal("setup did not quit after the last step")
+			}
+		})
+	}
+}
+
+func TestSetupWizardSavesConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	uitest.New(t, NewSetupModel()).
+		Type("http://forecaster:8081").Keys("enter").
+		Keys("enter").
+		Type("checkout").Keys("enter")
+
+	cfg, err := config.LoadConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ForecasterURL != "http://forecaster:8081" || cfg.ScalerURL != "http://localhost:8082" || cfg.Workload != "checkout" {
+		t.Errorf("saved config = %q, %q, %q", cfg.ForecasterURL, cfg.ScalerURL, cfg.Workload)
+	}
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/source"
+	"github.com/HatiCode/kedastral-tui/ui/panels"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// streamReconnectDelay is how long to wait before reopening a forecast
+// stream that ended; snapshots are polled in the meantime.
+const streamReconnectDelay = 5 * time.Second
+
+// streamMsg wraps a message received on a forecast stream, along with the
+// channel to wait on for the next one.
+type streamMsg struct {
+	msg tea.Msg
+	ch  <-chan tea.Msg
+}
+
+type streamEndedMsg struct {
+	workload string
+	gen      uint64
+	err      error
+}
+
+type streamReconnectMsg struct {
+	gen uint64
+}
+
+// openStream subscribes to forecasts for the current workload in the current
+// generation. It returns nil unless streaming is enabled.
+func (m Model) openStream() tea.Cmd {
+	c, ok := m.src.(source.Streamer)
+	if !m.streaming || !ok {
+		return nil
+	}
+
+	ctx, workload, leadTime, gen := m.fetchCtx, m.currentWorkload, m.cfg.LeadTime, m.fetchGen
+
+	return func() tea.Msg {
+		ch := make(chan tea.Msg, 16)
+		send := func(msg tea.Msg) {
+			select {
+			case ch <- msg:
+			case <-ctx.Done():
+			}
+		}
+
+		go func() {
+			defer close(ch)
+			err := c.StreamQuantileSnapshots(ctx, workload, leadTime, func(data *client.QuantileSnapshotData) {
+				send(quantileSnapshotMsg{workload: workload, gen: gen, data: data})
+			})
+			send(streamEndedMsg{workload: workload, gen: gen, err: err})
+		}()
+
+		return waitForStream(ch)()
+	}
+}
+
+// canStream reports whether src can push snapshots.
+func canStream(src source.ForecastSource) bool {
+	_, ok := src.(source.Streamer)
+	return ok
+}
+
+func waitForStream(ch <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return streamMsg{msg: msg, ch: ch}
+	}
+}
+
+// handleStream processes a stream message and keeps listening while the
+// stream belongs to the current generation.
+func (m Model) handleStream(msg streamMsg) (tea.Model, tea.Cmd) {
+	switch inner := msg.msg.(type) {
+	case quantileSnapshotMsg:
+		if !m.current(inner.workload, inner.gen) {
+			return m, nil
+		}
+		m.streamLive = true
+		if m.mode == ModePaused {
+			m.streamedHeld = &inner
+			return m, waitForStream(msg.ch)
+		}
+		next, cmd := m.Update(inner)
+		return next, tea.Batch(cmd, waitForStream(msg.ch))
+
+	case streamEndedMsg:
+		if !m.current(inner.workload, inner.gen) || errors.Is(inner.err, context.Canceled) {
+			return m, nil
+		}
+		m.streamLive = false
+
+		if errors.Is(inner.err, client.ErrStreamUnsupported) {
+			m.streaming = false
+			return m, tea.Batch(
+				m.fetchCurrent(),
+				func() tea.Msg {
+					return panels.NewLogMsg{Log: "Forecaster does not support streaming, falling back to polling"}
+				},
+			)
+		}
+
+		gen := m.fetchGen
+		return m, tea.Batch(
+			m.fetchCurrent(),
+			func() tea.Msg {
+				return panels.NewLogMsg{Log: fmt.Sprintf("Forecast stream ended: %v, reconnecting", inner.err)}
+			},
+			after(streamReconnectDelay, func(time.Time) tea.Msg {
+				return streamReconnectMsg{gen: gen}
+			}),
+		)
+	}
+
+	return m, nil
+}
+
+// resume restarts refreshing after a pause and applies the latest snapshot
+// streamed while paused, if it is still current.
+func (m Model) resume() (tea.Model, tea.Cmd) {
+	held := m.streamedHeld
+	m.streamedHeld = nil
+	if held == nil {
+		return m, tick(m.cfg.RefreshInterval)
+	}
+
+	next, cmd := m.Update(*held)
+	return next, tea.Batch(cmd, tick(m.cfg.RefreshInterval))
+}
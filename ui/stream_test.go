@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/internal/uitest"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModelStream(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	uitest.PinClock(t)
+
+	// The forecaster streams one snapshot per connection until it loses
+	// stream support
+	var supported atomic.Bool
+	supported.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/forecast/stream" || !supported.Load() {
+			http.NotFound(w, r)
+			return
+		}
+		data, _ := json.Marshal(uitest.Snapshot("checkout"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("event: snapshot\ndata: " + string(data) + "\n\n"))
+	}))
+	defer srv.Close()
+
+	cfg := testConfig()
+	cfg.Stream = true
+	m := NewModel(cfg, client.New(srv.URL, srv.URL, client.WithRetry(client.RetryPolicy{}), client.WithCircuitBreaker(0, 0)))
+	m.restartFetch()
+
+	// update delivers msg to m and returns its command
+	update := func(msg tea.Msg) tea.Cmd {
+		t.Helper()
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		return cmd
+	}
+
+	msg := m.openStream()()
+	update(msg)
+	if !m.streamLive || m.quantileSnapshot == nil {
+		t.Fatalf("live = %v, snapshot = %v after a streamed event; want live with a snapshot", m.streamLive, m.quantileSnapshot)
+	}
+
+	// The server closes the stream after the event; the model polls and
+	// schedules a reconnect
+	ended := waitForStream(msg.(streamMsg).ch)()
+	if cmd := update(ended); cmd == nil {
+		t.Fatalf("no commands after the stream ended, want a poll and a reconnect")
+	}
+	if m.streamLive || !m.streaming {
+		t.Fatalf("live = %v, streaming = %v after the stream ended; want polling until reconnected", m.streamLive, m.streaming)
+	}
+
+	supported.Store(false)
+	reconnect := update(streamReconnectMsg{gen: m.fetchGen})
+	if reconnect == nil {
+		t.Fatalf("reconnect did not reopen the stream")
+	}
+	update(reconnect())
+	if m.streaming {
+		t.Fatalf("still streaming after the forecaster stopped supporting it, want polling")
+	}
+	if cmd := update(streamReconnectMsg{gen: m.fetchGen}); cmd != nil {
+		t.Errorf("reconnected after falling back to polling")
+	}
+}
+
+func TestModelStreamWhilePaused(t *testing.T) {
+	d := newDriver(t, uitest.NewSource(), 120, 40).Keys(" ")
+
+	ch := make(chan tea.Msg)
+	close(ch)
+	for _, minutes := range []int{1, 2} {
+		snap := uitest.Snapshot("checkout")
+		snap.GeneratedAt = snap.GeneratedAt.Add(time.Duration(minutes) * time.Minute)
+		data := client.NewQuantileSnapshotData(snap, false, 5*time.Minute)
+		d.Send(streamMsg{msg: quantileSnapshotMsg{workload: "checkout", gen: d.Model().(Model).fetchGen, data: data}, ch: ch})
+	}
+
+	generated := func() time.Duration {
+		return d.Model().(Model).quantileSnapshot.Snapshot.GeneratedAt.Sub(uitest.Snapshot("checkout").GeneratedAt)
+	}
+	if got := generated(); got != 0 {
+		t.Fatalf("showing +%v while paused, want the snapshot from before the pause", got)
+	}
+
+	d.Keys(" ")
+	if got := generated(); got != 2*time.Minute {
+		t.Fatalf("showing +%v after resuming, want the latest streamed +2m", got)
+	}
+}
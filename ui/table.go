@@ -0,0 +1,288 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
+	"github.com/HatiCode/kedastral-tui/components"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tableState is the Tables tab's row cursor, sort order and hidden columns,
+// with the column picker and jump prompt that edit them.
+type tableState struct {
+	// cursor is the step under the row cursor; -1 follows the lead time
+	cursor int
+	sort   components.TableSort
+	hidden components.ColumnSet
+
+	picking bool
+	pick    int
+
+	prompting bool
+	input     string
+	inputErr  string
+}
+
+// tableActive reports whether keys drive the Tables tab.
+func (m Model) tableActive() bool {
+	return m.activeTab == TabTables && m.focusedPanel == PanelMain && !m.showHelp && !m.showOverview
+}
+
+// tableFor creates the Tables tab table for snap.
+func (m Model) tableFor(snap *client.QuantileSnapshotData, width int) *components.ReplicaTable {
+	table := components.NewReplicaTable(width)
+	table.SetActuals(m.actualsFor(snap))
+	table.SetSort(m.table.sort)
+	table.SetHidden(m.table.hidden)
+	if snap != nil {
+		table.SetCursor(m.tableCursor(snap))
+	}
+	return table
+}
+
+// tableColumns returns the Tables tab's columns for the displayed forecast.
+func (m Model) tableColumns() []components.TableColumn {
+	snap := m.displaySnapshot()
+	return m.tableFor(snap, 0).Columns(snap)
+}
+
+// tableCursor returns the step under the row cursor.
+func (m Model) tableCursor(snap *client.QuantileSnapshotData) int {
+	if m.table.cursor < 0 || m.table.cursor >= len(snap.Snapshot.DesiredReplicas) {
+		return snap.LeadTimeIndex
+	}
+	return m.table.cursor
+}
+
+// handleTableKey moves the row cursor, sets the lead time to the row under
+// it, or opens the column picker or jump prompt.
+func (m Model) handleTableKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "v":
+		m.table.picking = true
+		return m, nil
+	case "/":
+		m.table.prompting = true
+		m.table.input, m.table.inputErr = "", ""
+		return m, nil
+	}
+
+	snap := m.displaySnapshot()
+	if snap == nil {
+		return m, nil
+	}
+
+	order := m.tableFor(snap, 0).Order(snap)
+	if len(order) == 0 {
+		return m, nil
+	}
+	pos := slices.Index(order, m.tableCursor(snap))
+	page := max(m.tabViewport(TabTables).Height-4, 1)
+
+	switch msg.String() {
+	case "up", "k":
+		pos--
+	case "down", "j":
+		pos++
+	case "pgup", "ctrl+u":
+		pos -= page
+	case "pgdown", "ctrl+d":
+		pos += page
+	case "home", "g":
+		pos = 0
+	case "end", "G":
+		pos = len(order) - 1
+	case "enter":
+		m.setLeadStep(snap, order[pos])
+		return m, nil
+	}
+
+	pos = min(max(pos, 0), len(order)-1)
+	m.moveTableCursor(snap, order[pos])
+	return m, nil
+}
+
+// moveTableCursor puts the row cursor on step i and scrolls its row into
+// view.
+func (m *Model) moveTableCursor(snap *client.QuantileSnapshotData, i int) {
+	m.table.cursor = i
+
+	vp := m.tabViewport(TabTables)
+	line := m.tableFor(snap, 0).CursorLine(snap)
+	if line < vp.YOffset {
+		vp.YOffset = line
+	} else if line >= vp.YOffset+vp.Height {
+		vp.YOffset = line - vp.Height + 1
+	}
+	m.tabViewports[TabTables] = vp
+}
+
+// handleTablePicker selects a column, shows or hides it, or sorts by it.
+func (m Model) handleTablePicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	// The selection stays in range when a forecast has fewer columns
+	columns := m.tableColumns()
+	m.table.pick = min(m.table.pick, len(columns)-1)
+
+	switch msg.String() {
+	case "left", "h":
+		m.table.pick = (m.table.pick + len(columns) - 1) % len(columns)
+	case "right", "l":
+		m.table.pick = (m.table.pick + 1) % len(columns)
+	case " ":
+		m.table.hidden = m.table.hidden.Toggle(columns[m.table.pick])
+	case "enter":
+		// Sorting by the sorted column again reverses it
+		column := columns[m.table.pick]
+		if m.table.sort.Column == column {
+			m.table.sort.Desc = !m.table.sort.Desc
+		} else {
+			m.table.sort = components.TableSort{Column: column}
+		}
+	case "esc", "escape", "v":
+		m.table.picking = false
+	}
+	return m, nil
+}
+
+// handleTablePrompt edits the jump prompt and, on enter, moves the row cursor
+// to the step it names.
+func (m Model) handleTablePrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "enter":
+		snap := m.displaySnapshot()
+		if snap == nil {
+			m.table.prompting = false
+			return m, nil
+		}
+
+		order := m.tableFor(snap, 0).Order(snap)
+		i, err := jumpTarget(m.table.input, snap, order, slices.Index(order, m.tableCursor(snap)))
+		if err != nil {
+			m.table.inputErr = err.Error()
+			return m, nil
+		}
+		m.table.prompting = false
+		m.moveTableCursor(snap, i)
+	case "esc", "escape":
+		m.table.prompting = false
+	case "backspace":
+		if len(m.table.input) > 0 {
+			m.table.input = m.table.input[:len(m.table.input)-1]
+		}
+		m.table.inputErr = ""
+	default:
+		if len(key) == 1 {
+			m.table.input += key
+			m.table.inputErr = ""
+		}
+	}
+	return m, nil
+}
+
+// jumpTarget returns the step named by input: a clock time (09:45), an
+// offset from the forecast's start (+15m), or a replica count, which finds
+// the next row after pos with that many desired replicas.
+func jumpTarget(input string, snap *client.QuantileSnapshotData, order []int, pos int) (int, error) {
+	s := snap.Snapshot
+	n := len(s.DesiredReplicas)
+	step := time.Duration(s.StepSeconds) * time.Second
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	if replicas, err := strconv.Atoi(input); err == nil {
+		for k := 1; k <= len(order); k++ {
+			if i := order[(pos+k)%len(order)]; s.DesiredReplicas[i] == replicas {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("no step with %d replicas", replicas)
+	}
+
+	var offset time.Duration
+	switch {
+	case input == "now":
+	case strings.Contains(input, ":"):
+		t, err := parseClock(input, clock.Local(s.GeneratedAt))
+		if err != nil {
+			return 0, err
+		}
+		offset = t.Sub(s.GeneratedAt)
+	default:
+		d, err := time.ParseDuration(strings.TrimPrefix(input, "+"))
+		if err != nil {
+			return 0, fmt.Errorf("enter a time (09:45), an offset (+15m) or a replica count")
+		}
+		offset = d
+	}
+
+	if step <= 0 || offset < -step/2 || offset > time.Duration(n-1)*step+step/2 {
+		return 0, fmt.Errorf("%s is outside the forecast", input)
+	}
+	return min(max(int(math.Round(float64(offset)/float64(step))), 0), n-1), nil
+}
+
+// parseClock parses a 15:04 or 15:04:05 time on the day of ref, choosing the
+// day before or after when that is closer.
+func parseClock(input string, ref time.Time) (time.Time, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, input); err == nil {
+			t = time.Date(ref.Year(), ref.Month(), ref.Day(), t.Hour(), t.Minute(), t.Second(), 0, ref.Location())
+			if d := t.Sub(ref); d > 12*time.Hour {
+				t = t.AddDate(0, 0, -1)
+			} else if d < -12*time.Hour {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", input)
+}
+
+// tableFooter returns the Tables tab footer: the column picker or jump
+// prompt when open, otherwise its key hints.
+func (m Model) tableFooter() string {
+	inputStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	selectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+
+	switch {
+	case m.table.prompting:
+		hint := mutedStyle.Render("  (09:45, +15m or a replica count; esc to cancel)")
+		if m.table.inputErr != "" {
+			hint = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("  " + m.table.inputErr)
+		}
+		return "Jump to: " + inputStyle.Render(m.table.input+"█") + hint
+
+	case m.table.picking:
+		columns := m.tableColumns()
+		pick := min(m.table.pick, len(columns)-1)
+		items := make([]string, len(columns))
+		for i, c := range columns {
+			name := c.String()
+			if m.table.sort != (components.TableSort{}) && c == m.table.sort.Column {
+				name += "▲"
+				if m.table.sort.Desc {
+					name = strings.TrimSuffix(name, "▲") + "▼"
+				}
+			}
+
+			switch {
+			case i == pick:
+				items[i] = selectedStyle.Render("[" + name + "]")
+			case m.table.hidden.Has(c):
+				items[i] = mutedStyle.Render(name)
+			default:
+				items[i] = name
+			}
+		}
+		return "Columns: " + strings.Join(items, " ") + mutedStyle.Render("  Space hide · Enter sort")
+	}
+
+	return mutedStyle.Render("[↑↓] row  [Enter] set lead  [V] columns  [/] jump  [H] help  [Q] quit")
+}
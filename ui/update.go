@@ -1,13 +1,16 @@
 package ui
 
 import (
-	"context"
 	"fmt"
 	"time"
 
+	"github.com/HatiCode/kedastral-tui/accuracy"
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/HatiCode/kedastral-tui/components"
 	"github.com/HatiCode/kedastral-tui/config"
+	"github.com/HatiCode/kedastral-tui/session"
+	"github.com/HatiCode/kedastral-tui/source"
 	"github.com/HatiCode/kedastral-tui/ui/panels"
 	"github.com/HatiCode/kedastral-tui/ui/theme"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,16 +21,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.showContexts {
+			return m.handleContextSwitcher(msg)
+		}
+
+		// Typing into the bottom panel must not trigger global shortcuts
+		if m.bottomPanel != nil && m.focusedPanel == PanelBottom && m.bottomPanel.Capturing() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			*m.bottomPanel, cmd = m.bottomPanel.Update(msg)
+			return m, cmd
+		}
+
+		// The jump prompt takes every key while open
+		if m.table.prompting && msg.String() != "ctrl+c" {
+			return m.handleTablePrompt(msg)
+		}
+
+		if m.replay != nil {
+			if next, cmd, ok := m.handleReplayKey(msg); ok {
+				return next, cmd
+			}
+		}
+
+		if m.showHelp {
+			switch msg.String() {
+			case "j", "down", "k", "up", "g", "G", "ctrl+d", "ctrl+u", "pgdown", "pgup":
+				return m.scrollHelp(msg), nil
+			}
+		}
+
+		if m.showOverview && m.focusedPanel == PanelMain && !m.showHelp {
+			switch msg.String() {
+			case "esc", "escape", "o", "left", "right", "up", "down", "j", "k", "enter":
+				return m.handleOverview(msg)
+			}
+		}
+
 		switch msg.String() {
 		case "tab", "shift+tab", "w", "m", "[", "]":
 			return m.handleFocusSwitch(msg)
 		}
 
+		if m.scrubbing && m.focusedPanel == PanelMain {
+			switch msg.String() {
+			case "left", "right", "home", "end", "esc", "escape", "s":
+				return m.handleScrub(msg)
+			}
+		}
+
+		if m.chartCursorActive() {
+			if m.cursorMode {
+				switch msg.String() {
+				case "left", "right", "home", "end", "enter", "esc", "escape":
+					return m.handleChartCursor(msg)
+				}
+			}
+			switch msg.String() {
+			case "enter":
+				m.cursorMode = true
+				return m, nil
+			case "z", "Z", "shift+left", "shift+right":
+				return m.handleChartZoom(msg)
+			case "p":
+				return m.cycleHighlight()
+			}
+		}
+
+		if m.tableActive() {
+			if m.table.picking {
+				switch msg.String() {
+				case "left", "right", "h", "l", " ", "enter", "esc", "escape", "v":
+					return m.handleTablePicker(msg)
+				}
+			}
+			switch msg.String() {
+			case "up", "down", "k", "j", "pgup", "pgdown", "ctrl+u", "ctrl+d", "home", "end", "g", "G", "enter", "v", "/":
+				return m.handleTableKey(msg)
+			}
+		}
+
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 		case "h":
 			m.showHelp = !m.showHelp
+			m.helpOffset = 0
 		case " ":
 			if m.showHelp {
 				m.showHelp = false
@@ -35,12 +113,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = ModePaused
 			} else {
 				m.mode = ModeLive
-				return m, tick(m.cfg.RefreshInterval)
+				if m.replay == nil {
+					return m.resume()
+				}
+			}
+		case "s":
+			if !m.showHelp && !m.showOverview && m.focusedPanel == PanelMain {
+				return m.toggleScrub()
 			}
 		case "r":
 			if !m.showHelp {
 				m.loading = true
-				return m, fetchData(m.client, m.currentWorkload, m.cfg.LeadTime)
+				return m, m.refetch()
 			}
 		case "escape", "esc":
 			if m.showHelp {
@@ -85,7 +169,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.loading = true
 				m.err = nil
 				m.toastManager.Add("Retrying...", components.ToastInfo, 1*time.Second)
-				return m, fetchData(m.client, m.currentWorkload, m.cfg.LeadTime)
+				return m, m.refetch()
+			}
+		case "x":
+			if !m.showHelp {
+				return m.openContextSwitcher()
+			}
+		case "o":
+			if !m.showHelp {
+				return m.toggleOverview()
 			}
 		case "t":
 			if !m.showHelp {
@@ -102,50 +194,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case tea.MouseMsg:
+		if m.chartCursorActive() && !m.showContexts {
+			return m.handleChartMouse(msg)
+		}
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.layoutMgr.SetTerminalSize(msg.Width, msg.Height)
 		m.ready = true
-
-		layout := m.layoutMgr.Compute()
-		contentHeight := layout.Main.H - 10
-		contentWidth := layout.Main.W - 4
-		if contentHeight < 5 {
-			contentHeight = 5
-		}
-		if contentWidth < 10 {
-			contentWidth = 10
-		}
-
-		for tabID, vp := range m.tabViewports {
-			vp.Width = contentWidth
-			vp.Height = contentHeight
-			m.tabViewports[tabID] = vp
-		}
+		m.resizePanels()
 
 	case tickMsg:
-		if m.mode == ModeLive {
+		if m.mode == ModeLive && m.replay == nil {
 			m.loading = true
-			return m, tea.Batch(
+			batch := []tea.Cmd{
 				tick(m.cfg.RefreshInterval),
-				fetchData(m.client, m.cfg.Workload, m.cfg.LeadTime),
-			)
-		}
-
-	case snapshotMsg:
-		m.loading = false
-		m.lastUpdate = time.Now()
-		if msg.err != nil {
-			m.err = msg.err
-		} else {
-			m.snapshot = msg.data
-			m.err = nil
+				m.fetchCurrent(),
+			}
+			if m.showOverview && len(m.workloads) > 0 {
+				batch = append(batch, m.refreshOverview())
+			}
+			return m, tea.Batch(batch...)
 		}
 
 	case quantileSnapshotMsg:
+		if !m.current(msg.workload, msg.gen) {
+			break
+		}
+		cmds = append(cmds, m.recordSource(sourceForecast, msg.err))
+		m.record(session.Record{Kind: session.KindSnapshot, Workload: msg.workload, Snapshot: msg.data}, msg.err)
 		m.loading = false
-		m.lastUpdate = time.Now()
+		m.lastUpdate = clock.Now()
 		if msg.err != nil {
 			m.err = msg.err
 		} else {
@@ -153,6 +234,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.apiVersion = msg.data.APIVersion
 			m.err = nil
 
+			// Keep the scrubbed snapshot in view when the ring drops its oldest entry
+			if _, evicted := m.history.Add(msg.workload, msg.data); evicted && m.scrubbing && m.scrubIndex > 0 {
+				m.scrubIndex--
+			}
+
 			if m.bottomPanel != nil {
 				m.bottomPanel.UpdateAPIVersion(msg.data.APIVersion)
 			}
@@ -162,9 +248,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					Log: fmt.Sprintf("Forecast received, age: %.1fs", msg.data.ForecastAge.Seconds()),
 				}
 			})
+
+			if cmd := m.refreshObserved(msg.data); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+
+	case observedMsg:
+		if m.current(msg.workload, msg.gen) {
+			if msg.err != nil {
+				cmds = append(cmds, func() tea.Msg {
+					return panels.NewLogMsg{Log: fmt.Sprintf("Observed values unavailable: %v", msg.err)}
+				})
+			} else {
+				m.observed = msg.data
+				m.rescoreAccuracy()
+			}
 		}
 
 	case scalerMetricsMsg:
+		if !m.current(msg.workload, msg.gen) {
+			break
+		}
+		cmds = append(cmds, m.recordSource(sourceScalerMetrics, msg.err))
+		m.record(session.Record{Kind: session.KindScalerMetrics, Workload: msg.workload, Metrics: msg.data}, msg.err)
 		if msg.err == nil {
 			m.scalerMetrics = msg.data
 			// Update bottom panel metrics
@@ -174,24 +281,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case healthMsg:
-		m.forecasterHealthy = msg.forecasterHealthy
-		m.scalerHealthy = msg.scalerHealthy
+		if msg.gen != m.fetchGen {
+			break
+		}
+		cmds = append(cmds, m.recordSource(msg.source, msg.err))
+		switch msg.source {
+		case sourceForecasterHealth:
+			m.forecasterHealthy = msg.err == nil
+			m.record(session.Record{Kind: session.KindHealth, Endpoint: session.EndpointForecaster}, msg.err)
+		case sourceScalerHealth:
+			m.scalerHealthy = msg.err == nil
+			m.record(session.Record{Kind: session.KindHealth, Endpoint: session.EndpointScaler}, msg.err)
+		}
 
 	case workloadListMsg:
 		if msg.err == nil && len(msg.workloads) > 0 {
+			m.record(session.Record{Kind: session.KindWorkloads, Workloads: msg.workloads}, nil)
 			m.workloads = msg.workloads
 			layout := m.layoutMgr.Compute()
 			sidebar := panels.NewSidebar(msg.workloads, layout.Sidebar.W, layout.Sidebar.H)
 			m.sidebar = &sidebar
+
+			if m.overviewIndex >= len(msg.workloads) {
+				m.overviewIndex = len(msg.workloads) - 1
+			}
+			if m.showOverview && m.replay == nil {
+				cmds = append(cmds, m.refreshOverview())
+			}
+		}
+
+	case streamMsg:
+		return m.handleStream(msg)
+
+	case streamReconnectMsg:
+		if msg.gen == m.fetchGen && m.streaming {
+			return m, m.openStream()
+		}
+
+	case overviewMsg:
+		if m.showOverview && msg.gen == m.overviewGen {
+			m.overview = msg.entries
+		}
+
+	case replayTickMsg:
+		if m.replay != nil {
+			return m.advanceReplay(time.Time(msg))
 		}
 
 	case panels.WorkloadSelectedMsg:
+		if m.replay != nil {
+			m.toastManager.Add("The replay follows the recorded workload", components.ToastWarning, 2*time.Second)
+			break
+		}
 		m.currentWorkload = msg.Workload
+		m.resetWorkloadData()
 		m.loading = true
-		return m, fetchData(m.client, msg.Workload, m.cfg.LeadTime)
+		return m, m.refetch()
 
 	case panels.TabSwitchMsg:
 		m.activeTab = TabID(msg.TabID)
+		m.cursorMode = false
+		if !m.scrubbable() {
+			m.scrubbing = false
+		}
 	}
 
 	var spinnerCmd tea.Cmd
@@ -215,10 +367,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
-		if vp, ok := m.tabViewports[m.activeTab]; ok {
+		if _, ok := m.tabViewports[m.activeTab]; ok {
 			if keyMsg, ok := msg.(tea.KeyMsg); ok {
 				switch keyMsg.String() {
 				case "j", "down", "k", "up", "g", "G", "ctrl+d", "ctrl+u", "pgdown", "pgup":
+					vp := m.tabViewport(m.activeTab)
 					vp, cmd = vp.Update(msg)
 					m.tabViewports[m.activeTab] = vp
 					if cmd != nil {
@@ -253,35 +406,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func fetchData(c *client.Client, workload string, leadTime time.Duration) tea.Cmd {
+// recordSource records the outcome of a request to source and returns a
+// command logging the change when the source starts failing or recovers.
+func (m *Model) recordSource(source sourceID, err error) tea.Cmd {
+	if m.bottomPanel != nil {
+		m.bottomPanel.UpdateBreakers(m.breakerStatuses())
+	}
+
+	if !m.sources[source].record(err, clock.Now()) {
+		return nil
+	}
+
+	log := fmt.Sprintf("%s recovered", source)
+	if err != nil {
+		log = fmt.Sprintf("%s failed: %v", source, err)
+	}
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+		return panels.NewLogMsg{Log: log}
+	}
+}
+
+// breakerStatuses returns the circuit breakers guarding the source, if any.
+func (m Model) breakerStatuses() []client.BreakerStatus {
+	if r, ok := m.src.(source.BreakerReporter); ok {
+		return r.BreakerStatuses()
+	}
+	return nil
+}
+
+// refreshObserved fetches observed values covering every recorded snapshot of
+// the current workload, at most once per forecast step.
+func (m *Model) refreshObserved(latest *client.QuantileSnapshotData) tea.Cmd {
+	observed, ok := m.src.(source.ObservedSource)
+	step := time.Duration(latest.Snapshot.StepSeconds) * time.Second
+	if !ok || m.replay != nil || step <= 0 || clock.Since(m.observedFetchedAt) < step {
+		return nil
+	}
+	m.observedFetchedAt = clock.Now()
 
-		quantileSnapshotCh := make(chan quantileSnapshotMsg, 1)
-		metricsCh := make(chan scalerMetricsMsg, 1)
+	start := latest.Snapshot.GeneratedAt
+	if ring := m.history.Get(m.currentWorkload); ring != nil && ring.Len() > 0 {
+		start = ring.At(0).Snapshot.GeneratedAt
+	}
 
-		go func() {
-			data, err := c.GetQuantileSnapshot(ctx, workload, leadTime)
-			quantileSnapshotCh <- quantileSnapshotMsg{data: data, err: err}
-		}()
+	return fetchObserved(m.fetchCtx, observed, m.currentWorkload, latest.Snapshot.Metric, start, clock.Now(), step, m.fetchGen)
+}
 
-		go func() {
-			data, err := c.GetScalerMetrics(ctx)
-			metricsCh <- scalerMetricsMsg{data: data, err: err}
-		}()
+// rescoreAccuracy scores the recorded snapshots of the current workload
+// against the latest observed values.
+func (m *Model) rescoreAccuracy() {
+	ring := m.history.Get(m.currentWorkload)
+	if ring == nil || ring.Len() == 0 {
+		m.accuracy = nil
+		return
+	}
 
-		quantileSnapshot := <-quantileSnapshotCh
-		metrics := <-metricsCh
+	latest := ring.Latest().Snapshot
+	width := accuracy.BucketWidth(latest.StepSeconds, latest.HorizonSeconds)
+	report := accuracy.Score(ring.Items(), m.observed, width)
+	m.accuracy = &report
+}
 
-		forecasterHealthy, scalerHealthy := c.GetHealthStatus(ctx)
+// actualsFor aligns the observed values with the steps of snapshot.
+func (m Model) actualsFor(snapshot *client.QuantileSnapshotData) []float64 {
+	if snapshot == nil || m.observed == nil || len(m.observed.Samples) == 0 {
+		return nil
+	}
 
-		tea.Batch(
-			func() tea.Msg { return quantileSnapshot },
-			func() tea.Msg { return metrics },
-			func() tea.Msg { return healthMsg{forecasterHealthy, scalerHealthy} },
-		)
+	snap := snapshot.Snapshot
+	step := time.Duration(snap.StepSeconds) * time.Second
+	return m.observed.Align(snap.GeneratedAt, step, len(snap.DesiredReplicas))
+}
 
-		return quantileSnapshot
+// scrollHelp scrolls the help screen.
+func (m Model) scrollHelp(msg tea.KeyMsg) Model {
+	vp := m.helpViewport()
+	switch msg.String() {
+	case "g":
+		vp.GotoTop()
+	case "G":
+		vp.GotoBottom()
+	default:
+		vp, _ = vp.Update(msg)
 	}
+	m.helpOffset = vp.YOffset
+	return m
 }
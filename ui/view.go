@@ -3,9 +3,12 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HatiCode/kedastral-tui/client"
+	"github.com/HatiCode/kedastral-tui/clock"
 	"github.com/HatiCode/kedastral-tui/components"
+	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -30,8 +33,11 @@ func (m Model) View() string {
 	}
 
 	if m.showHelp {
-		help := components.NewHelp(m.width)
-		return help.Render()
+		return m.renderHelp()
+	}
+
+	if m.showContexts {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.renderContextSwitcher())
 	}
 
 	// Compute layout dimensions
@@ -77,9 +83,12 @@ func (m Model) View() string {
 			lipgloss.Right, lipgloss.Top,
 			toastContent,
 		)
+		// Toasts cover the rows they appear on, leaving the height unchanged
 		lines := strings.Split(result, "\n")
-		if len(lines) > 0 {
-			lines[0] = toastOverlay
+		for i, toast := range strings.Split(toastOverlay, "\n") {
+			if i < len(lines) && strings.TrimSpace(toast) != "" {
+				lines[i] = toast
+			}
 		}
 		result = strings.Join(lines, "\n")
 	}
@@ -87,6 +96,21 @@ func (m Model) View() string {
 	return result
 }
 
+// renderHelp shows the part of the help screen scrolled into view, above a
+// line of scrolling hints.
+func (m Model) renderHelp() string {
+	vp := m.helpViewport()
+	footer := mutedStyle.Render(fmt.Sprintf("[J/K] scroll  [H/Esc] close  %3.f%%", vp.ScrollPercent()*100))
+	return vp.View() + "\n" + footer
+}
+
+func (m Model) helpViewport() viewport.Model {
+	vp := viewport.New(m.width, m.height-1)
+	vp.SetContent(components.NewHelp(m.width).Render())
+	vp.SetYOffset(m.helpOffset)
+	return vp
+}
+
 func (m Model) renderSidebar(width, height int) string {
 	if width == 0 {
 		return ""
@@ -95,8 +119,8 @@ func (m Model) renderSidebar(width, height int) string {
 	focused := m.focusedPanel == PanelSidebar
 
 	borderStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
+		Width(width - 2).
+		Height(height - 2).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(borderColor(focused))
 
@@ -116,7 +140,7 @@ func (m Model) renderSidebar(width, height int) string {
 			)
 	}
 
-	return borderStyle.Render(content)
+	return borderStyle.Render(clip(content, width-2, height-2))
 }
 
 func (m Model) renderMain(width, height int) string {
@@ -127,76 +151,144 @@ func (m Model) renderMain(width, height int) string {
 	focused := m.focusedPanel == PanelMain
 
 	borderStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
+		Width(width - 2).
+		Height(height - 2).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(borderColor(focused))
 
-	// Status bar
+	statusBarContent, tabBar, footer := m.mainChrome(width)
+	vp := m.tabViewport(m.activeTab)
+
+	if m.showOverview {
+		overview, selectedLine := m.renderOverview(width - 4)
+		vp.SetContent(overview)
+
+		// Keep the selected card in view
+		if selectedLine < vp.YOffset || selectedLine+overviewCardHeight > vp.YOffset+vp.Height {
+			vp.SetYOffset(selectedLine)
+		}
+
+		return m.renderMainFrame(width, height, borderStyle, statusBarContent, tabBar, vp.View(), footer)
+	}
+
+	var tabContent string
+	switch m.activeTab {
+	case TabCharts:
+		if snap := m.displaySnapshot(); snap != nil {
+			tabContent = m.quantileChart(snap, width-4, chartHeight(height)).Render(snap) +
+				"\n\n" + m.replicaChart(snap, width-4).Render(snap)
+		} else {
+			tabContent = components.NewQuantileChart(width-4, chartHeight(height)).Render(nil)
+		}
+
+	case TabTables:
+		snap := m.displaySnapshot()
+		replicaTable := m.tableFor(snap, width-4)
+
+		// Keep the cursor's row in view when the data under it changes. The
+		// offset is clamped to the content, so set that first.
+		vp.SetContent(replicaTable.Render(snap))
+		if line := replicaTable.CursorLine(snap); line >= 0 && (line < vp.YOffset || line >= vp.YOffset+vp.Height) {
+			vp.SetYOffset(max(line-vp.Height+1, 0))
+		}
+		return m.renderMainFrame(width, height, borderStyle, statusBarContent, tabBar, vp.View(), footer)
+
+	case TabConfig:
+		tabContent = m.renderConfigView(width - 4)
+
+	case TabLogs:
+		tabContent = "Logs view\n(Will show forecast/scaler logs)"
+
+	case TabAccuracy:
+		accuracyTable := components.NewAccuracyTable(width - 4)
+		tabContent = accuracyTable.Render(m.accuracy, m.cfg.LeadTime)
+
+	default:
+		tabContent = "Unknown tab"
+	}
+
+	vp.SetContent(tabContent)
+
+	return m.renderMainFrame(width, height, borderStyle, statusBarContent, tabBar, vp.View(), footer)
+}
+
+// mainChrome renders the main panel's status bar, tab bar and footer for a
+// panel width wide, wrapped to the content width.
+func (m Model) mainChrome(width int) (statusBar, tabBar, footer string) {
 	modeStr := "LIVE"
 	if m.mode == ModePaused {
 		modeStr = "PAUSED"
 	}
+	position := m.scrubStatus()
+	if m.replay != nil {
+		modeStr = "REPLAY"
+		if m.mode == ModePaused {
+			modeStr = "REPLAY PAUSED"
+		}
+		if position == "" {
+			position = m.replayStatus()
+		}
+	}
 
-	statusBar := components.NewStatusBar(width - 4)
-	statusBarContent := statusBar.Render(
+	statusBar = components.NewStatusBar(width-4).Render(
 		m.currentWorkload,
 		modeStr,
+		position,
 		m.lastUpdate,
-		m.snapshot,
+		m.quantileSnapshot,
 		m.scalerMetrics,
 		m.forecasterHealthy,
 		m.scalerHealthy,
+		m.breakerStatuses(),
 		m.loading,
 		m.spinner.View(),
 		m.err,
 	)
 
-	// Tab bar
-	var tabBar string
 	if m.mainTabs != nil {
 		tabBar = m.mainTabs.View()
 	} else {
 		tabBar = lipgloss.NewStyle().Bold(true).Render(
-			"[■ Charts] | Tables | Config | Logs",
+			"[■ Charts] | Tables | Config | Logs | Accuracy",
 		)
 	}
 
-	vp := m.tabViewports[m.activeTab]
-	var tabContent string
-	switch m.activeTab {
-	case TabCharts:
-		chartHeight := 10
-		if height > 30 {
-			chartHeight = 12
-		}
-		if m.quantileSnapshot != nil {
-			quantileChart := components.NewQuantileChart(width-4, chartHeight)
-			tabContent = quantileChart.Render(m.quantileSnapshot)
-		} else {
-			forecastChart := components.NewForecastChart(width-4, chartHeight)
-			tabContent = forecastChart.Render(m.snapshot)
-		}
-
-	case TabTables:
-		replicaTable := components.NewReplicaTable(width - 4)
-		tabContent = replicaTable.Render(m.snapshot)
+	footer = mutedStyle.Render("[Tab] focus  [1-5] tabs  [h/l] navigate  [SPACE] pause  [H] help  [Q] quit")
+	if m.replay != nil {
+		footer = mutedStyle.Render("[SPACE] play/pause  [,/.] step  [</>] seek 1m  [+/-] speed  [H] help  [Q] quit")
+	}
 
-	case TabConfig:
-		tabContent = m.renderConfigView(width - 4)
+	switch {
+	case m.showOverview:
+		tabBar = ""
+		footer = mutedStyle.Render("[←↑↓→] select  [Enter] open workload  [O/Esc] close  [H] help  [Q] quit")
+	case m.cursorMode && m.chartCursorActive():
+		footer = mutedStyle.Render("[←/→] move cursor  [Home/End] first/last  [Enter/Esc] done  [Z] zoom  [P] highlight")
+	case m.activeTab == TabTables && m.focusedPanel == PanelMain && m.replay == nil:
+		footer = m.tableFooter()
+	}
 
-	case TabLogs:
-		tabContent = "Logs view\n(Will show forecast/scaler logs)"
+	wrap := lipgloss.NewStyle().Width(width - 4)
+	return wrap.Render(statusBar), wrap.Render(tabBar), wrap.Render(footer)
+}
 
-	default:
-		tabContent = "Unknown tab"
-	}
+// tabViewport returns the viewport of tab sized to the rows the main panel
+// leaves between its header and footer.
+func (m Model) tabViewport(tab TabID) viewport.Model {
+	main := m.layoutMgr.Compute().Main
+	statusBar, tabBar, footer := m.mainChrome(main.W)
 
-	vp.SetContent(tabContent)
-	mainContent := vp.View()
+	// The border and the blank lines around the content take four rows
+	chrome := lipgloss.Height(statusBar) + lipgloss.Height(tabBar) + lipgloss.Height(footer) + 4
 
-	footer := mutedStyle.Render("[Tab] focus  [1-4] tabs  [h/l] navigate  [SPACE] pause  [H] help  [Q] quit")
+	vp := m.tabViewports[tab]
+	vp.Width = max(main.W-4, 10)
+	vp.Height = max(main.H-chrome, 1)
+	return vp
+}
 
+// renderMainFrame lays out the main panel sections inside its border.
+func (m Model) renderMainFrame(width, height int, borderStyle lipgloss.Style, statusBarContent, tabBar, mainContent, footer string) string {
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		statusBarContent,
 		tabBar,
@@ -206,13 +298,7 @@ func (m Model) renderMain(width, height int) string {
 		footer,
 	)
 
-	// Ensure content fits in the bordered area
-	content = lipgloss.NewStyle().
-		Width(width - 4).
-		Height(height - 4).
-		Render(content)
-
-	return borderStyle.Render(content)
+	return borderStyle.Render(clip(content, width-2, height-2))
 }
 
 func (m Model) renderBottom(width, height int) string {
@@ -223,8 +309,8 @@ func (m Model) renderBottom(width, height int) string {
 	focused := m.focusedPanel == PanelBottom
 
 	borderStyle := lipgloss.NewStyle().
-		Width(width).
-		Height(height).
+		Width(width - 2).
+		Height(height - 2).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(borderColor(focused))
 
@@ -237,13 +323,12 @@ func (m Model) renderBottom(width, height int) string {
 		content = "Loading..."
 	}
 
-	// Ensure content fits in bordered area
-	content = lipgloss.NewStyle().
-		Width(width - 4).
-		Height(height - 4).
-		Render(content)
+	return borderStyle.Render(clip(content, width-2, height-2))
+}
 
-	return borderStyle.Render(content)
+// clip cuts content to fit inside a border of the given inner size.
+func clip(content string, width, height int) string {
+	return lipgloss.NewStyle().MaxWidth(width).MaxHeight(height).Render(content)
 }
 
 func borderColor(focused bool) lipgloss.Color {
@@ -276,6 +361,39 @@ func renderScalerInfo(metrics *client.ScalerMetrics) string {
 	return s.String()
 }
 
+// renderSources lists each data source with its last success and error.
+func (m Model) renderSources() string {
+	var s strings.Builder
+
+	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	for id := range sourceCount {
+		state := m.sources[id]
+
+		status := mutedStyle.Render("-")
+		switch {
+		case state.err != nil:
+			status = errorStyle.Render("✗")
+		case !state.lastSuccess.IsZero():
+			status = successStyle.Render("✓")
+		}
+
+		last := "never"
+		if !state.lastSuccess.IsZero() {
+			last = fmt.Sprintf("%s ago", clock.Since(state.lastSuccess).Round(time.Second))
+		}
+
+		s.WriteString(fmt.Sprintf("  %-18s %s  last success: %s", id, status, last))
+		if state.err != nil {
+			s.WriteString(errorStyle.Render(fmt.Sprintf("  %v", state.err)))
+		}
+		s.WriteString("\n")
+	}
+
+	return s.String()
+}
+
 func (m Model) renderConfigView(width int) string {
 	var s strings.Builder
 
@@ -284,12 +402,13 @@ func (m Model) renderConfigView(width int) string {
 	s.WriteString(titleStyle.Render("Workload Configuration"))
 	s.WriteString("\n\n")
 
-	if m.snapshot != nil {
-		s.WriteString(fmt.Sprintf("  Workload:        %s\n", m.cfg.Workload))
-		s.WriteString(fmt.Sprintf("  Metric:          %s\n", m.snapshot.Snapshot.Metric))
-		s.WriteString(fmt.Sprintf("  Step Duration:   %ds\n", m.snapshot.Snapshot.StepSeconds))
-		s.WriteString(fmt.Sprintf("  Horizon:         %ds\n", m.snapshot.Snapshot.HorizonSeconds))
-		s.WriteString(fmt.Sprintf("  Generated At:    %s\n", m.snapshot.Snapshot.GeneratedAt.Format("15:04:05")))
+	if snap := m.quantileSnapshot; snap != nil {
+		s.WriteString(fmt.Sprintf("  Workload:        %s\n", m.currentWorkload))
+		s.WriteString(fmt.Sprintf("  Metric:          %s\n", snap.Snapshot.Metric))
+		s.WriteString(fmt.Sprintf("  Step Duration:   %ds\n", snap.Snapshot.StepSeconds))
+		s.WriteString(fmt.Sprintf("  Horizon:         %ds\n", snap.Snapshot.HorizonSeconds))
+		s.WriteString(fmt.Sprintf("  Generated At:    %s\n", clock.Local(snap.Snapshot.GeneratedAt).Format("15:04:05")))
+		s.WriteString(fmt.Sprintf("  API Version:     v%d\n", snap.APIVersion))
 	}
 
 	s.WriteString("\n")
@@ -301,12 +420,26 @@ func (m Model) renderConfigView(width int) string {
 	}
 
 	s.WriteString("\n\n")
+	s.WriteString(titleStyle.Render("Data Sources"))
+	s.WriteString("\n\n")
+	s.WriteString(m.renderSources())
+
+	s.WriteString("\n")
 	s.WriteString(titleStyle.Render("TUI Configuration"))
 	s.WriteString("\n\n")
+	if m.cfg.CurrentContext != "" {
+		s.WriteString(fmt.Sprintf("  Context:         %s\n", m.cfg.CurrentContext))
+	}
 	s.WriteString(fmt.Sprintf("  Forecaster URL:  %s\n", m.cfg.ForecasterURL))
 	s.WriteString(fmt.Sprintf("  Scaler URL:      %s\n", m.cfg.ScalerURL))
 	s.WriteString(fmt.Sprintf("  Refresh Interval: %s\n", m.cfg.RefreshInterval))
 	s.WriteString(fmt.Sprintf("  Lead Time:       %s\n", m.cfg.LeadTime))
 
+	updates := "polling"
+	if m.streaming {
+		updates = "event stream"
+	}
+	s.WriteString(fmt.Sprintf("  Forecast Updates: %s\n", updates))
+
 	return s.String()
 }
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minZoomSteps is the fewest steps the Charts tab zooms in to.
+const minZoomSteps = 4
+
+// handleChartZoom zooms the chart around the cursor or pans the zoomed
+// window across the horizon.
+func (m Model) handleChartZoom(msg tea.KeyMsg) (Model, tea.Cmd) {
+	snap := m.displaySnapshot()
+	if snap == nil {
+		return m, nil
+	}
+
+	n := len(snap.Snapshot.DesiredReplicas)
+	steps := m.chartSteps
+	if steps <= 0 || steps > n {
+		steps = n
+	}
+
+	switch msg.String() {
+	case "z":
+		steps = max(steps/2, min(minZoomSteps, n))
+		m.chartStart = snap.LeadTimeIndex - steps/2
+	case "Z":
+		center := m.chartStart + steps/2
+		steps *= 2
+		m.chartStart = center - steps/2
+	case "shift+left":
+		m.chartStart -= max(steps/4, 1)
+	case "shift+right":
+		m.chartStart += max(steps/4, 1)
+	}
+
+	if steps >= n {
+		m.chartStart, m.chartSteps = 0, 0
+		return m, nil
+	}
+	m.chartSteps = steps
+	m.chartStart = min(max(m.chartStart, 0), n-steps)
+	return m, nil
+}